@@ -1,20 +1,46 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"gitlab-cli-sdk/internal/cli"
 	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/i18n"
 )
 
 // Version 应用程序版本号，通过编译时 -ldflags 注入
 var Version = "dev"
 
 func main() {
+	// 命令帮助在构建命令时生成，因此先于参数解析确定语言
+	if err := i18n.SetLang(i18n.Detect(os.Args[1:])); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	cfg := &config.CLIConfig{}
 	rootCmd := cli.BuildRootCommand(cfg, Version)
 
-	if err := rootCmd.Execute(); err != nil {
+	// SIGINT/SIGTERM 取消 context，让正在进行的操作干净地停止并报告剩余工作
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		stop()
+		if rootCmd.SilenceErrors {
+			slog.Error(i18n.T("命令执行失败"), "error", err)
+		}
+		// 部分失败和全部失败使用不同的退出码，便于 CI 区分
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -3,8 +3,11 @@ module gitlab-cli-sdk
 go 1.23.0
 
 require (
+	filippo.io/age v1.2.1
+	github.com/BurntSushi/toml v1.4.0
 	github.com/spf13/cobra v1.8.0
 	gitlab.com/gitlab-org/api/client-go v0.157.0
+	golang.org/x/time v0.12.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -14,7 +17,8 @@ require (
 	github.com/hashicorp/go-retryablehttp v0.7.8 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/crypto v0.24.0 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
 	golang.org/x/oauth2 v0.30.0 // indirect
-	golang.org/x/time v0.12.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
 )
@@ -0,0 +1,243 @@
+// Package audit 把通过 pkg/client 发出的每个修改性 API 调用追加写入审计日志，
+// 记录谁（CheckAuth 确认的身份和 sudo 用户）在哪次运行中对哪个资源做了什么，以及结果。
+//
+// 审计日志是只追加的 NDJSON 文件，每次写入都重新以 O_APPEND 打开，不会改写已有记录；
+// 请求参数中的密码和已登记的凭证会被隐藏。
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/pkg/client"
+)
+
+// PathEnv 指定默认的审计日志路径，便于在共享机器上集中存放
+const PathEnv = "GITLAB_CLI_AUDIT_LOG"
+
+// Record 是审计日志中的一条记录
+type Record struct {
+	Time      time.Time      `json:"time"`
+	RunID     string         `json:"run_id,omitempty"`
+	Actor     string         `json:"actor"`
+	Sudo      string         `json:"sudo,omitempty"`
+	Operation string         `json:"operation"`
+	Target    string         `json:"target"`
+	Params    map[string]any `json:"params,omitempty"`
+	Status    int            `json:"status"`
+	Attempts  int            `json:"attempts"`
+	Duration  float64        `json:"duration"` // 秒
+	Error     string         `json:"error,omitempty"`
+}
+
+// Failed 判断调用是否失败
+func (r Record) Failed() bool {
+	return r.Error != ""
+}
+
+// DefaultPath 返回默认的审计日志路径：PathEnv，其次是 $XDG_STATE_HOME/gitlab-cli/audit.ndjson，
+// 再次是 ~/.local/state/gitlab-cli/audit.ndjson
+func DefaultPath() string {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path
+	}
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(os.TempDir(), "gitlab-cli", "audit.ndjson")
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "gitlab-cli", "audit.ndjson")
+}
+
+// Journal 把 client.AuditEntry 写入审计日志，实现 client.Auditor
+type Journal struct {
+	mu    sync.Mutex
+	path  string
+	runID string
+}
+
+// Open 检查审计日志可以写入并返回 Journal，目录不存在时创建（权限 0700）
+func Open(path, runID string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create audit log directory: %w", err)
+	}
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	return &Journal{path: path, runID: runID}, nil
+}
+
+// Path 返回审计日志路径
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Audit 追加一条记录。写入失败不影响已完成的 API 调用，只记录错误日志
+func (j *Journal) Audit(entry client.AuditEntry) {
+	record := Record{
+		Time:      entry.Time.UTC(),
+		RunID:     j.runID,
+		Actor:     entry.Actor,
+		Sudo:      entry.Sudo,
+		Operation: entry.Operation,
+		Target:    entry.Target,
+		Params:    redactParams(entry.Params),
+		Status:    entry.Status,
+		Attempts:  entry.Attempts,
+		Duration:  entry.Duration.Round(time.Millisecond).Seconds(),
+	}
+	if entry.Err != nil {
+		record.Error = redact.String(entry.Err.Error())
+	}
+	if err := j.append(record); err != nil {
+		slog.Error(i18n.T("写入审计日志失败"), "path", j.path, "operation", entry.Operation, "target", entry.Target, "error", err)
+	}
+}
+
+// append 以 O_APPEND 打开审计日志并写入一行，多个进程同时写入时每行保持完整
+func (j *Journal) append(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	file, err := openAppend(j.path)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func openAppend(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	return file, nil
+}
+
+// redactParams 隐藏参数中已登记的凭证；client 已把密码替换为 client.Redacted
+func redactParams(params map[string]any) map[string]any {
+	if len(params) == 0 {
+		return nil
+	}
+	redacted := make(map[string]any, len(params))
+	for key, value := range params {
+		if s, ok := value.(string); ok {
+			value = redact.String(s)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// Read 读取审计日志中的所有记录
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return records, nil
+}
+
+// Filter 筛选审计记录，零值字段不参与筛选
+type Filter struct {
+	RunID     string
+	Actor     string
+	Sudo      string
+	Operation string // 不区分大小写
+	Target    string // 目标中包含该字符串，或为 <类型>:<路径> 或 <类型>:<ID> 时即匹配
+	Since     time.Time
+	Until     time.Time
+	Failed    bool // 只保留失败的调用
+}
+
+// Match 判断记录是否满足所有筛选条件
+func (f Filter) Match(r Record) bool {
+	switch {
+	case f.RunID != "" && r.RunID != f.RunID:
+		return false
+	case f.Actor != "" && r.Actor != f.Actor:
+		return false
+	case f.Sudo != "" && r.Sudo != f.Sudo:
+		return false
+	case f.Operation != "" && !strings.EqualFold(r.Operation, f.Operation):
+		return false
+	case f.Target != "" && !matchTarget(r.Target, f.Target):
+		return false
+	case !f.Since.IsZero() && r.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !r.Time.Before(f.Until):
+		return false
+	case f.Failed && !r.Failed():
+		return false
+	}
+	return true
+}
+
+// matchTarget 判断目标是否匹配 query。目标的格式是 <类型>:<路径>#<ID>，同一资源的创建和删除
+// 记录目标相同，因此 user:alice 和 user:7 都匹配 user:alice#7；其余 query 按子串匹配
+func matchTarget(target, query string) bool {
+	if strings.Contains(target, query) {
+		return true
+	}
+	kind, ref, ok := strings.Cut(query, ":")
+	if !ok {
+		return false
+	}
+	targetKind, rest, _ := strings.Cut(target, ":")
+	path, id, _ := strings.Cut(rest, "#")
+	return kind == targetKind && (ref == path || ref == id)
+}
+
+// Select 返回满足筛选条件的记录
+func Select(records []Record, f Filter) []Record {
+	var selected []Record
+	for _, record := range records {
+		if f.Match(record) {
+			selected = append(selected, record)
+		}
+	}
+	return selected
+}
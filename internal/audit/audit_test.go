@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/pkg/client"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "audit.ndjson")
+	journal, err := Open(path, "20261018100000000-a1b2")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	started := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	redact.Add("glpat-audit-secret")
+	journal.Audit(client.AuditEntry{
+		Time: started, Operation: "CreateUser", Actor: "root", Target: "user:bot#7",
+		Params: map[string]any{"username": "bot", "password": client.Redacted}, Status: 201, Attempts: 1,
+	})
+	journal.Audit(client.AuditEntry{
+		Time: started.Add(time.Minute), Operation: "CreatePersonalAccessToken", Actor: "root", Target: "user:bot#7",
+		Params: map[string]any{"name": "glpat-audit-secret"}, Status: 500, Attempts: 3,
+		Err: errors.New("token glpat-audit-secret rejected"),
+	})
+	journal.Audit(client.AuditEntry{
+		Time: started.Add(2 * time.Minute), Operation: "CreateGroup", Actor: "root", Sudo: "bot", Target: "group:team#12", Status: 201, Attempts: 1,
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "glpat-audit-secret") {
+		t.Errorf("audit log contains a registered secret:\n%s", data)
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0600 {
+		t.Errorf("audit log mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	records, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	if records[0].RunID != "20261018100000000-a1b2" || records[0].Params["password"] != client.Redacted {
+		t.Errorf("first record = %+v", records[0])
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all", Filter{}, []string{"CreateUser", "CreatePersonalAccessToken", "CreateGroup"}},
+		{"failed", Filter{Failed: true}, []string{"CreatePersonalAccessToken"}},
+		{"sudo", Filter{Sudo: "bot"}, []string{"CreateGroup"}},
+		{"operation", Filter{Operation: "createuser"}, []string{"CreateUser"}},
+		{"target", Filter{Target: "user:"}, []string{"CreateUser", "CreatePersonalAccessToken"}},
+		{"target path", Filter{Target: "user:bot"}, []string{"CreateUser", "CreatePersonalAccessToken"}},
+		{"target id", Filter{Target: "group:12"}, []string{"CreateGroup"}},
+		{"target id of another kind", Filter{Target: "user:12"}, nil},
+		{"since", Filter{Since: started.Add(time.Minute)}, []string{"CreatePersonalAccessToken", "CreateGroup"}},
+		{"until", Filter{Until: started.Add(time.Minute)}, []string{"CreateUser"}},
+		{"other run", Filter{RunID: "other"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, record := range Select(records, tt.filter) {
+				got = append(got, record.Operation)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Select = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
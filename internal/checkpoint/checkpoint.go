@@ -0,0 +1,203 @@
+package checkpoint
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"gitlab-cli-sdk/internal/utils"
+	"gitlab-cli-sdk/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// State is the persisted content of a checkpoint file.
+type State struct {
+	ConfigFile string      `yaml:"config_file"`      // ConfigFile records the config the run was started from
+	RunID      string      `yaml:"run_id,omitempty"` // RunID is the identifier all generated names of the run derive from
+	Users      []UserEntry `yaml:"users"`            // Users holds one entry per user touched by the run, in config order
+}
+
+// UserEntry tracks the progress of a single user from the config.
+type UserEntry struct {
+	Username  string            `yaml:"username"`        // Username is the logical username from the config
+	Completed bool              `yaml:"completed"`       // Completed is set once every resource of the user was processed
+	Names     map[string]string `yaml:"names,omitempty"` // Names maps resource keys to the names generated for them
+	Output    types.UserOutput  `yaml:"output"`          // Output is the partial or final output of the user
+}
+
+// Checkpoint persists run progress after each resource so an interrupted run can be resumed.
+// A nil *Checkpoint is valid and disables checkpointing.
+type Checkpoint struct {
+	mu    sync.Mutex
+	path  string
+	state State
+}
+
+// New creates an empty checkpoint for the run runID that will be written to path.
+func New(path, configFile, runID string) *Checkpoint {
+	return &Checkpoint{
+		path:  path,
+		state: State{ConfigFile: configFile, RunID: runID},
+	}
+}
+
+// Load reads an existing checkpoint file so the run can continue from it.
+func Load(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint file: %w", err)
+	}
+
+	var state State
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse checkpoint file: %w", err)
+	}
+
+	return &Checkpoint{path: path, state: state}, nil
+}
+
+// Path returns the file the checkpoint is written to.
+func (c *Checkpoint) Path() string {
+	if c == nil {
+		return ""
+	}
+	return c.path
+}
+
+// ConfigFile returns the config file recorded when the checkpoint was created.
+func (c *Checkpoint) ConfigFile() string {
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state.ConfigFile
+}
+
+// RunID returns the run ID recorded when the checkpoint was created.
+func (c *Checkpoint) RunID() string {
+	if c == nil {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state.RunID
+}
+
+// Name returns the name previously generated for key, or calls generate and records the result.
+// The checkpoint is saved before the name is returned, so a crash right after creating the
+// resource still leaves the name on disk. Nothing is recorded when generate fails.
+func (c *Checkpoint) Name(username, key string, generate func() (string, error)) (string, error) {
+	if c == nil {
+		return generate()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(username)
+	if name, ok := entry.Names[key]; ok {
+		return name, nil
+	}
+
+	name, err := generate()
+	if err != nil {
+		return "", err
+	}
+	if entry.Names == nil {
+		entry.Names = make(map[string]string)
+	}
+	entry.Names[key] = name
+	return name, c.save()
+}
+
+// Output returns the output recorded for username by a previous run, if any.
+func (c *Checkpoint) Output(username string) (*types.UserOutput, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.state.Users {
+		if c.state.Users[i].Username == username {
+			output := c.state.Users[i].Output
+			return &output, true
+		}
+	}
+	return nil, false
+}
+
+// Completed reports whether username was fully processed and returns its final output.
+func (c *Checkpoint) Completed(username string) (*types.UserOutput, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.state.Users {
+		if c.state.Users[i].Username == username && c.state.Users[i].Completed {
+			output := c.state.Users[i].Output
+			return &output, true
+		}
+	}
+	return nil, false
+}
+
+// Record stores the current output of username and saves the checkpoint.
+func (c *Checkpoint) Record(username string, output *types.UserOutput) error {
+	if c == nil || output == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entry(username).Output = *output
+	return c.save()
+}
+
+// Complete marks username as fully processed with its final output and saves the checkpoint.
+func (c *Checkpoint) Complete(username string, output *types.UserOutput) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(username)
+	if output != nil {
+		entry.Output = *output
+	}
+	entry.Completed = true
+	return c.save()
+}
+
+// entry returns the entry for username, appending a new one when missing. Callers hold c.mu.
+func (c *Checkpoint) entry(username string) *UserEntry {
+	for i := range c.state.Users {
+		if c.state.Users[i].Username == username {
+			return &c.state.Users[i]
+		}
+	}
+	c.state.Users = append(c.state.Users, UserEntry{Username: username})
+	return &c.state.Users[len(c.state.Users)-1]
+}
+
+// save writes the checkpoint atomically. The file holds passwords and token values, so it is
+// only readable by the owner. Callers hold c.mu.
+func (c *Checkpoint) save() error {
+	data, err := yaml.Marshal(&c.state)
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+
+	if err := utils.WriteFileAtomic(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("write checkpoint file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// TestCheckpointResume verifies that generated names and completed users survive a reload,
+// which is what allows `user create --resume` to continue without creating duplicates.
+func TestCheckpointResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.checkpoint")
+
+	cp := New(path, "users.yaml", "20251030150000123-a1b2")
+	name, err := cp.Name("alice", "username", func() (string, error) { return "alice-1", nil })
+	if err != nil {
+		t.Fatalf("Name() error = %v", err)
+	}
+	if name != "alice-1" {
+		t.Fatalf("Name() = %q, want %q", name, "alice-1")
+	}
+	if err := cp.Complete("alice", &types.UserOutput{Username: "alice-1", UserID: 7}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if _, err := cp.Name("bob", "username", func() (string, error) { return "bob-1", nil }); err != nil {
+		t.Fatalf("Name() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat checkpoint: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("checkpoint mode = %o, want 600", perm)
+	}
+
+	resumed, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := resumed.ConfigFile(); got != "users.yaml" {
+		t.Errorf("ConfigFile() = %q, want %q", got, "users.yaml")
+	}
+	if got := resumed.RunID(); got != "20251030150000123-a1b2" {
+		t.Errorf("RunID() = %q, want %q", got, "20251030150000123-a1b2")
+	}
+
+	output, ok := resumed.Completed("alice")
+	if !ok || output.UserID != 7 {
+		t.Errorf("Completed(alice) = %+v, %v; want user 7, true", output, ok)
+	}
+	if _, ok := resumed.Completed("bob"); ok {
+		t.Errorf("Completed(bob) = true, want false")
+	}
+
+	name, err = resumed.Name("bob", "username", func() (string, error) { return "bob-2", nil })
+	if err != nil {
+		t.Fatalf("Name() error = %v", err)
+	}
+	if name != "bob-1" {
+		t.Errorf("resumed Name(bob) = %q, want the previously generated %q", name, "bob-1")
+	}
+}
+
+// TestNilCheckpoint verifies that a nil checkpoint is a no-op so callers need no guards.
+func TestNilCheckpoint(t *testing.T) {
+	var cp *Checkpoint
+
+	name, err := cp.Name("alice", "username", func() (string, error) { return "generated", nil })
+	if err != nil || name != "generated" {
+		t.Errorf("Name() = %q, %v; want %q, nil", name, err, "generated")
+	}
+	if err := cp.Record("alice", &types.UserOutput{}); err != nil {
+		t.Errorf("Record() error = %v", err)
+	}
+	if _, ok := cp.Completed("alice"); ok {
+		t.Errorf("Completed() = true, want false")
+	}
+}
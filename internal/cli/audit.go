@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gitlab-cli-sdk/internal/audit"
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+// auditOption 打开 --audit-log 指定的审计日志，返回把修改性 API 调用写入其中的客户端选项；
+// --audit-log 为空时不记录。默认路径打不开时（例如 CI 中 $HOME 不可写）只告警并不记录，
+// 用户明确指定的路径打不开时返回错误
+func auditOption(cfg *config.CLIConfig, runID string) (client.Option, error) {
+	if cfg.AuditLog == "" {
+		return client.WithAuditor(nil), nil
+	}
+	journal, err := audit.Open(cfg.AuditLog, runID)
+	if err != nil {
+		if !isDefaultAuditLog(cfg.AuditLog) {
+			return nil, err
+		}
+		slog.Warn(i18n.T("无法打开默认审计日志，本次运行不记录审计日志"), "path", cfg.AuditLog, "error", err)
+		return client.WithAuditor(nil), nil
+	}
+	slog.Debug(i18n.T("审计日志"), "path", journal.Path())
+	return client.WithAuditor(journal), nil
+}
+
+// isDefaultAuditLog 判断 path 是否为未经 --audit-log 或环境变量指定的默认审计日志路径
+func isDefaultAuditLog(path string) bool {
+	return os.Getenv(audit.PathEnv) == "" && path == audit.DefaultPath()
+}
+
+// buildAuditCommand 构建审计日志相关命令
+func buildAuditCommand(cfg *config.CLIConfig) *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: i18n.T("审计日志相关命令"),
+	}
+
+	auditCmd.AddCommand(buildAuditShowCommand(cfg))
+
+	return auditCmd
+}
+
+// buildAuditShowCommand 构建查看审计日志的命令
+func buildAuditShowCommand(cfg *config.CLIConfig) *cobra.Command {
+	var filter audit.Filter
+	var since, until string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: i18n.T("查看和筛选审计日志"),
+		Long: i18n.T(`列出审计日志中记录的修改性 API 调用（创建和删除用户、组、项目和 Token 等），
+可以按运行 ID、调用者、sudo 用户、操作、目标、时间和结果筛选。
+--since 和 --until 接受 RFC 3339 时间、YYYY-MM-DD 日期，或 24h 这样表示多久之前的时长。
+
+示例:
+  gitlab-cli audit show --since 24h
+  gitlab-cli audit show --run-id 20251030150000123-a1b2 --failed
+  gitlab-cli audit show --operation DeleteUser --actor root --json`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			now := time.Now()
+			if filter.Since, err = parseTimeFlag(since, now); err != nil {
+				return fmt.Errorf("--since: %w", err)
+			}
+			if filter.Until, err = parseTimeFlag(until, now); err != nil {
+				return fmt.Errorf("--until: %w", err)
+			}
+			return runAuditShow(cfg.AuditLog, filter, asJSON, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.RunID, "run-id", "", i18n.T("只显示该运行 ID 的调用"))
+	cmd.Flags().StringVar(&filter.Actor, "actor", "", i18n.T("只显示该调用者（Token 所属用户）的调用"))
+	cmd.Flags().StringVar(&filter.Sudo, "sudo", "", i18n.T("只显示以该用户身份（sudo）发出的调用"))
+	cmd.Flags().StringVar(&filter.Operation, "operation", "", i18n.T("只显示该操作，例如 CreateUser、DeleteGroup"))
+	cmd.Flags().StringVar(&filter.Target, "target", "", i18n.T("只显示目标匹配的调用：user:alice 和 user:7 都匹配用户 alice（ID 7）的创建和删除，其余值按子串匹配"))
+	cmd.Flags().StringVar(&since, "since", "", i18n.T("只显示该时间之后的调用"))
+	cmd.Flags().StringVar(&until, "until", "", i18n.T("只显示该时间之前的调用"))
+	cmd.Flags().BoolVar(&filter.Failed, "failed", false, i18n.T("只显示失败的调用"))
+	cmd.Flags().BoolVar(&asJSON, "json", false, i18n.T("以 NDJSON 格式输出原始记录"))
+
+	return cmd
+}
+
+// runAuditShow 读取审计日志并把筛选后的记录输出到 w
+func runAuditShow(path string, filter audit.Filter, asJSON bool, w io.Writer) error {
+	records, err := audit.Read(path)
+	if err != nil {
+		return err
+	}
+	selected := audit.Select(records, filter)
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		for _, record := range selected {
+			if err := enc.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tRUN_ID\tACTOR\tSUDO\tOPERATION\tTARGET\tSTATUS\tERROR")
+	for _, record := range selected {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			record.Time.Local().Format(time.DateTime), record.RunID, record.Actor, record.Sudo,
+			record.Operation, record.Target, record.Status, firstLine(record.Error))
+	}
+	tw.Flush()
+	slog.Info(i18n.T("审计记录"), "matched", len(selected), "total", len(records))
+	return nil
+}
+
+// parseTimeFlag 解析 --since、--until：RFC 3339 时间、YYYY-MM-DD 日期（本地时区），
+// 或表示 now 之前多久的时长；空字符串返回零值
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf(i18n.T("无法解析时间 %q（应为 RFC 3339 时间、YYYY-MM-DD 日期或 24h 这样的时长）"), value)
+}
+
+// firstLine 返回多行错误信息的第一行，避免破坏表格
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab-cli-sdk/internal/audit"
+	"gitlab-cli-sdk/internal/config"
+)
+
+// TestAuditOptionUnwritableStateDir verifies that an unwritable default audit log only disables
+// journaling, while the same path given explicitly is still an error.
+func TestAuditOptionUnwritableStateDir(t *testing.T) {
+	// 以文件作为状态目录的父目录，即使以 root 运行也无法在其下创建目录
+	blocker := filepath.Join(t.TempDir(), "state")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(audit.PathEnv, "")
+	t.Setenv("XDG_STATE_HOME", filepath.Join(blocker, "xdg"))
+
+	cfg := &config.CLIConfig{AuditLog: audit.DefaultPath()}
+	if opt, err := auditOption(cfg, "run"); err != nil || opt == nil {
+		t.Fatalf("auditOption(default) = %v, %v; want fallback without error", opt, err)
+	}
+
+	explicit := &config.CLIConfig{AuditLog: filepath.Join(blocker, "audit.ndjson")}
+	if _, err := auditOption(explicit, "run"); err == nil {
+		t.Error("auditOption(--audit-log) error = nil, want open error")
+	}
+
+	t.Setenv(audit.PathEnv, cfg.AuditLog)
+	if _, err := auditOption(cfg, "run"); err == nil {
+		t.Error("auditOption(env) error = nil, want open error")
+	}
+}
@@ -1,17 +1,33 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"text/tabwriter"
 	"time"
 
+	"gitlab-cli-sdk/internal/audit"
+	"gitlab-cli-sdk/internal/checkpoint"
 	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/encrypt"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/naming"
 	"gitlab-cli-sdk/internal/processor"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
 	"gitlab-cli-sdk/internal/template"
+	"gitlab-cli-sdk/internal/tracing"
+	"gitlab-cli-sdk/internal/utils"
 	"gitlab-cli-sdk/pkg/client"
 	"gitlab-cli-sdk/pkg/types"
 
@@ -23,9 +39,9 @@ import (
 func BuildRootCommand(cfg *config.CLIConfig, version string) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:     "gitlab-cli",
-		Short:   "GitLab 用户和项目自动化管理工具（使用 GitLab Go SDK）",
+		Short:   i18n.T("GitLab 用户和项目自动化管理工具（使用 GitLab Go SDK）"),
 		Version: version,
-		Long: `GitLab CLI 是基于官方 GitLab Go SDK 的用户和项目自动化管理工具。
+		Long: i18n.T(`GitLab CLI 是基于官方 GitLab Go SDK 的用户和项目自动化管理工具。
 它通过 YAML 配置文件批量创建和管理 GitLab 用户、组和项目。
 
 特性：
@@ -35,11 +51,53 @@ func BuildRootCommand(cfg *config.CLIConfig, version string) *cobra.Command {
   - 更好的性能和错误处理
 
 前置要求：
-  - GitLab 管理员权限的 Personal Access Token (api + sudo scopes)`,
+  - GitLab 管理员权限的 Personal Access Token (api + sudo scopes)
+
+退出码：
+  0  全部成功
+  1  配置、认证等错误，或操作被取消
+  2  部分资源操作失败
+  3  全部资源操作失败`),
+		// 参数解析通过后的运行错误不再打印用法说明
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			// main 在构建命令前已按 --lang 设置语言，这里只校验取值
+			if cfg.Lang != "" {
+				if err := i18n.SetLang(cfg.Lang); err != nil {
+					return err
+				}
+			}
+			// 日志和错误信息中隐藏已登记的密码和 Token
+			if err := logging.Setup(logging.Options{
+				Level:       cfg.LogLevel,
+				Format:      cfg.LogFormat,
+				File:        cfg.LogFile,
+				ShowSecrets: cfg.ShowSecrets,
+			}); err != nil {
+				return err
+			}
+			cmd.Root().SetErr(logging.Console())
+			// json 日志格式下错误也以 json 记录，由 main 输出
+			cmd.Root().SilenceErrors = logging.IsJSON()
+			// 读取配置和输出结果时透明解密
+			return encrypt.LoadIdentities(cfg.Identities)
+		},
 	}
+	rootCmd.PersistentFlags().BoolVar(&cfg.ShowSecrets, "show-secrets", false, i18n.T("在日志中显示密码和 Token（默认隐藏）"))
+	rootCmd.PersistentFlags().StringVar(&cfg.LogLevel, "log-level", "info", i18n.T("日志级别: debug、info、warn 或 error"))
+	rootCmd.PersistentFlags().StringVar(&cfg.LogFormat, "log-format", logging.FormatText, i18n.T("日志格式: text 或 json"))
+	rootCmd.PersistentFlags().StringVar(&cfg.LogFile, "log-file", "", i18n.T("日志追加写入的文件（默认输出到标准错误）"))
+	rootCmd.PersistentFlags().StringVar(&cfg.Lang, "lang", "", i18n.T("消息语言: zh 或 en（默认根据 LANG 判断）"))
+	rootCmd.PersistentFlags().StringVar(&cfg.AuditLog, "audit-log", audit.DefaultPath(), i18n.Tf("追加记录修改性 API 调用的审计日志（为空时不记录，默认路径可通过 %s 环境变量修改）", audit.PathEnv))
+	rootCmd.PersistentFlags().StringArrayVar(&cfg.Identities, "identity", nil, i18n.Tf("解密配置和输出结果使用的 age 私钥文件，可重复指定（口令通过 %s 环境变量提供）", encrypt.PassphraseEnv))
 
 	// 添加子命令
 	rootCmd.AddCommand(buildUserCommand(cfg))
+	rootCmd.AddCommand(buildConfigCommand(cfg))
+	rootCmd.AddCommand(buildRenderCommand(cfg))
+	rootCmd.AddCommand(buildKeygenCommand())
+	rootCmd.AddCommand(buildAuditCommand(cfg))
+	rootCmd.AddCommand(buildVerifyCommand(cfg))
 
 	return rootCmd
 }
@@ -48,7 +106,7 @@ func BuildRootCommand(cfg *config.CLIConfig, version string) *cobra.Command {
 func buildUserCommand(cfg *config.CLIConfig) *cobra.Command {
 	userCmd := &cobra.Command{
 		Use:   "user",
-		Short: "用户管理命令",
+		Short: i18n.T("用户管理命令"),
 	}
 
 	userCmd.AddCommand(buildUserCreateCommand(cfg))
@@ -60,23 +118,85 @@ func buildUserCommand(cfg *config.CLIConfig) *cobra.Command {
 	return userCmd
 }
 
+// buildConfigCommand 构建配置文件相关命令
+func buildConfigCommand(cfg *config.CLIConfig) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: i18n.T("配置文件相关命令"),
+	}
+
+	configCmd.AddCommand(buildConfigExpandCommand(cfg))
+
+	return configCmd
+}
+
+// buildConfigExpandCommand 构建配置展开命令
+func buildConfigExpandCommand(cfg *config.CLIConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expand",
+		Short: i18n.T("输出展开 count 和 matrix 后的完整配置"),
+		Long: i18n.T(`读取配置文件，展开 count 和 matrix 并替换 ${index} 等变量后输出到标准输出，
+默认为 YAML 格式，可用 --format 输出 JSON 或 TOML。
+不连接 GitLab，可用于在创建前检查展开结果。
+
+示例:
+  gitlab-cli config expand -f config.yaml
+  gitlab-cli config expand -f config.toml --format json`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// --format 只决定输出格式，配置文件的格式根据扩展名判断
+			format, err := config.DetectFormat(config.StdioPath, cfg.Format)
+			if err != nil {
+				return err
+			}
+			userConfig, err := config.Load(cfg.ConfigFile, "")
+			if err != nil {
+				return err
+			}
+			return config.WriteConfig(cmd.OutOrStdout(), userConfig, format)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cfg.ConfigFile, "config", "f", "../test-users.yaml", i18n.T("配置文件路径（- 表示标准输入）"))
+	cmd.Flags().StringVar(&cfg.Format, "format", "", i18n.T("输出格式: yaml（默认）、json 或 toml"))
+
+	return cmd
+}
+
 // buildUserCreateCommand 构建用户创建命令
 func buildUserCreateCommand(cfg *config.CLIConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
-		Short: "根据配置文件创建用户、组和项目",
+		Short: i18n.T("根据配置文件创建用户、组和项目"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUserCreate(cfg)
+			return instrumentRun(cmd, cfg, result.ActionCreate, func(ctx context.Context) error {
+				return runUserCreate(ctx, cfg)
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&cfg.ConfigFile, "config", "f", "../test-users.yaml", "配置文件路径")
-	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", "GitLab 主机地址")
-	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", "GitLab Personal Access Token")
-	cmd.Flags().StringVar(&cfg.GitLabSSHEndpoint, "ssh-endpoint", "", "GitLab SSH endpoint (e.g., ssh://git@host:22)")
-	cmd.Flags().StringVarP(&cfg.OutputFile, "output", "o", "", "输出结果到 YAML 文件")
-	cmd.Flags().StringVarP(&cfg.TemplateFile, "template", "t", "", "使用模板文件格式化输出")
-	cmd.Flags().StringVar(&cfg.NameSuffix, "suffix", "", "Custom suffix appended after millisecond timestamp in prefix mode")
+	cmd.Flags().StringVarP(&cfg.ConfigFile, "config", "f", "../test-users.yaml", i18n.T("配置文件路径（- 表示标准输入）"))
+	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", i18n.T("GitLab 主机地址"))
+	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", i18n.T("GitLab 个人访问令牌（Personal Access Token）"))
+	addRetryFlags(cmd, cfg)
+	cmd.Flags().StringVar(&cfg.GitLabSSHEndpoint, "ssh-endpoint", "", i18n.T("GitLab SSH 地址（例如 ssh://git@host:22）"))
+	cmd.Flags().StringArrayVarP(&cfg.OutputFiles, "output", "o", nil, i18n.T("输出目标 path[:template[:mode]]，可重复指定；template 为模板文件或内置格式，省略时按扩展名输出 YAML、JSON 或 TOML（- 表示标准输出）；路径包含冒号且没有 template 时以 :: 结尾"))
+	addFormatFlag(cmd, cfg)
+	cmd.Flags().StringVarP(&cfg.TemplateFile, "template", "t", "", i18n.T("使用模板文件格式化未指定模板的 --output"))
+	cmd.Flags().BoolVar(&cfg.StrictTemplate, "strict-template", false, i18n.T("模板严格模式：访问不存在的键或未设置的环境变量时报错"))
+	cmd.Flags().BoolVar(&cfg.Redact, "redact", false, i18n.T("输出文件中的密码和 Token 替换为 ${<USERNAME>_TOKEN} 形式的引用（内置格式除外）"))
+	addEncryptFlags(cmd, cfg)
+	cmd.Flags().BoolVar(&cfg.EncryptFields, "encrypt-fields", false, i18n.T("只加密原始结果中的密码和 Token 字段，其余内容保持可读（模板和内置格式仍加密整个文件）"))
+	cmd.Flags().StringVar(&cfg.OutputFormat, "output-format", "", i18n.Tf("使用内置格式输出未指定模板的 --output: %s", strings.Join(template.Formats(), ", ")))
+	cmd.MarkFlagsMutuallyExclusive("template", "output-format")
+	cmd.Flags().StringVar(&cfg.NameSuffix, "suffix", "", i18n.T("prefix 模式下附加在毫秒时间戳之后的自定义后缀"))
+	cmd.Flags().StringVar(&cfg.RunID, "run-id", "", i18n.T("本次运行的 ID，prefix 模式生成的名称都以它结尾（默认由毫秒时间戳和后缀生成）"))
+	cmd.Flags().StringVar(&cfg.CheckpointFile, "checkpoint", "", i18n.T("断点文件路径，每完成一个资源就更新一次"))
+	cmd.Flags().StringVar(&cfg.ResumeFile, "resume", "", i18n.T("从断点文件恢复中断的创建流程（复用已生成的名称并跳过已完成的用户）"))
+	addConcurrencyFlags(cmd, cfg)
+	addEventsFlag(cmd, cfg)
+	addTraceFlags(cmd, cfg)
+	addMetricsFlag(cmd, cfg)
+	addReportFlag(cmd, cfg)
 
 	return cmd
 }
@@ -85,24 +205,36 @@ func buildUserCreateCommand(cfg *config.CLIConfig) *cobra.Command {
 func buildUserCleanupCommand(cfg *config.CLIConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cleanup",
-		Short: "清理配置文件中定义的用户",
-		Long: `清理配置文件中定义的用户及其所有资源。
+		Short: i18n.T("清理配置文件中定义的用户"),
+		Long: i18n.T(`清理配置文件中定义的用户及其所有资源。
 默认只删除创建日期超过2天的用户，可通过 --days-old 参数调整。
 设置 --days-old=0 将删除所有匹配的用户（不考虑创建时间）。
 
 示例:
   gitlab-cli user cleanup -f config.yaml                    # 只删除2天前创建的用户
   gitlab-cli user cleanup -f config.yaml --days-old 7       # 只删除7天前创建的用户
-  gitlab-cli user cleanup -f config.yaml --days-old 0       # 删除所有用户（不检查创建时间）`,
+  gitlab-cli user cleanup -f config.yaml --days-old 0       # 删除所有用户（不检查创建时间）
+  gitlab-cli user cleanup -f config.yaml --run-id 20251030150000123-a1b2  # 清理指定运行创建的资源`),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUserCleanup(cfg)
+			return instrumentRun(cmd, cfg, result.ActionDelete, func(ctx context.Context) error {
+				return runUserCleanup(ctx, cfg)
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&cfg.ConfigFile, "config", "f", "../test-users.yaml", "配置文件路径")
-	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", "GitLab 主机地址")
-	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", "GitLab Personal Access Token")
-	cmd.Flags().IntVar(&cfg.DaysOld, "days-old", 2, "只删除创建日期超过指定天数的用户（0表示删除所有用户）")
+	cmd.Flags().StringVarP(&cfg.ConfigFile, "config", "f", "../test-users.yaml", i18n.T("配置文件路径（- 表示标准输入）"))
+	addFormatFlag(cmd, cfg)
+	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", i18n.T("GitLab 主机地址"))
+	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", i18n.T("GitLab 个人访问令牌（Personal Access Token）"))
+	addRetryFlags(cmd, cfg)
+	cmd.Flags().IntVar(&cfg.DaysOld, "days-old", 2, i18n.T("只删除创建日期超过指定天数的用户（0表示删除所有用户）"))
+	cmd.Flags().StringVar(&cfg.RunID, "run-id", "", i18n.T("根据运行 ID 推导 prefix 模式生成的名称，使原始配置文件可直接用于清理"))
+	addConcurrencyFlags(cmd, cfg)
+	addWaitFlags(cmd, cfg)
+	addEventsFlag(cmd, cfg)
+	addTraceFlags(cmd, cfg)
+	addMetricsFlag(cmd, cfg)
+	addReportFlag(cmd, cfg)
 
 	return cmd
 }
@@ -113,76 +245,179 @@ func buildUserDeleteCommand(cfg *config.CLIConfig) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "delete",
-		Short: "删除指定用户名的用户及其项目和组",
-		Long: `根据用户名删除用户及其所有资源（项目和组）。
+		Short: i18n.T("删除指定用户名的用户及其项目和组"),
+		Long: i18n.T(`根据用户名删除用户及其所有资源（项目和组）。
 支持删除多个用户，用户名之间用逗号分隔。
 
 示例:
   gitlab-cli user delete --username user1
-  gitlab-cli user delete --username user1,user2,user3`,
+  gitlab-cli user delete --username user1,user2,user3`),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUserDelete(cfg, usernames)
+			return instrumentRun(cmd, cfg, result.ActionDelete, func(ctx context.Context) error {
+				return runUserDelete(ctx, cfg, usernames)
+			})
 		},
 	}
 
-	cmd.Flags().StringVar(&usernames, "username", "", "要删除的用户名（多个用户用逗号分隔）")
-	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", "GitLab 主机地址")
-	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", "GitLab Personal Access Token")
+	cmd.Flags().StringVar(&usernames, "username", "", i18n.T("要删除的用户名（多个用户用逗号分隔）"))
+	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", i18n.T("GitLab 主机地址"))
+	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", i18n.T("GitLab 个人访问令牌（Personal Access Token）"))
+	addRetryFlags(cmd, cfg)
+	addWaitFlags(cmd, cfg)
+	addEventsFlag(cmd, cfg)
+	addTraceFlags(cmd, cfg)
+	addMetricsFlag(cmd, cfg)
+	addReportFlag(cmd, cfg)
 	_ = cmd.MarkFlagRequired("username")
 
 	return cmd
 }
 
 // runUserCreate 执行用户创建命令
-func runUserCreate(cfg *config.CLIConfig) error {
-	gitlabClient, err := initializeClient(cfg)
+func runUserCreate(ctx context.Context, cfg *config.CLIConfig) error {
+	runID, err := resolveRunID(cfg)
+	if err != nil {
+		return err
+	}
+
+	gitlabClient, err := initializeAuditedClient(ctx, cfg, runID)
 	if err != nil {
 		return err
 	}
 	defer gitlabClient.CloseIdleConnections()
+	defer logRetrySummary(gitlabClient)
+	runStarted := time.Now()
 
-	userConfig, err := config.Load(cfg.ConfigFile)
+	userConfig, err := config.Load(cfg.ConfigFile, cfg.Format)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("\n找到 %d 个用户配置\n\n", len(userConfig.Users))
+	targets, err := outputTargets(cfg, userConfig.Outputs)
+	if err != nil {
+		return err
+	}
+	if err := checkOutputTargets(targets, cfg.GitLabSSHEndpoint != ""); err != nil {
+		return err
+	}
+	if err := checkEventsTarget(cfg, targets); err != nil {
+		return err
+	}
+	if err := checkTraceTarget(cfg, targets); err != nil {
+		return err
+	}
+	enc, err := newOutputEncryption(cfg)
+	if err != nil {
+		return err
+	}
+
+	slog.Info(i18n.T("找到用户配置"), "count", len(userConfig.Users))
 	if cfg.NameSuffix != "" {
-		log.Printf("使用自定义后缀: %s\n", cfg.NameSuffix)
+		slog.Info(i18n.T("使用自定义后缀"), "suffix", cfg.NameSuffix)
 	}
 
-	proc := &processor.ResourceProcessor{
-		Client:     gitlabClient,
-		NameSuffix: cfg.NameSuffix,
+	slog.Info(i18n.T("运行 ID"), "run_id", runID)
+	tracing.FromContext(ctx).SetAttributes(slog.String("run_id", runID))
+
+	cp, err := openCheckpoint(cfg, runID)
+	if err != nil {
+		return err
+	}
+	stream, err := openEvents(cfg, runID, result.ActionCreate)
+	if err != nil {
+		return err
 	}
+	defer stream.Close()
 
-	// 收集所有用户的输出结果
-	var userOutputs []types.UserOutput
+	proc := &processor.ResourceProcessor{
+		Client:       gitlabClient,
+		Namer:        naming.New(runID),
+		SharedGroups: processor.SharedGroupPaths(userConfig.Users),
+		Checkpoint:   cp,
+		Events:       stream,
+	}
 
+	// 按配置顺序收集所有用户的输出结果和操作记录，保证并发处理时输出顺序确定
+	results := make([]*types.UserOutput, len(userConfig.Users))
+	recorders := make([]*result.Recorder, len(userConfig.Users))
 	for i, userSpec := range userConfig.Users {
-		log.Printf("==========================================\n")
-		log.Printf("处理用户 [%d/%d]: %s\n", i+1, len(userConfig.Users), userSpec.Username)
-		log.Printf("==========================================\n")
+		recorders[i] = result.NewRecorder(userSpec.Username)
+	}
 
-		userOutput, err := proc.ProcessUserCreation(userSpec)
+	// 单个用户失败不影响其他用户，失败记录在结果汇总中
+	utils.RunParallel(len(userConfig.Users), cfg.Concurrency, false, func(i int) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		userSpec := userConfig.Users[i]
+		logger := userLogger(userSpec.Username, result.ActionCreate)
+		recorder := recorders[i]
+		started := time.Now()
+
+		logger.Info(i18n.T("处理用户"), "index", i+1, "total", len(userConfig.Users))
+
+		// 断点续跑：上次运行已完成的用户直接复用其输出
+		if completed, ok := cp.Completed(userSpec.Username); ok {
+			logger.Info(i18n.T("用户已在上次运行中完成，跳过"), "username", completed.Username)
+			recorder.SetUser(completed.Username)
+			recorder.Skipped(result.KindUser, completed.Username, result.ActionCreate, i18n.T("上次运行已完成"))
+			results[i] = completed
+			return nil
+		}
+
+		userOutput, err := proc.ForUser(logger, recorder).ProcessUserCreation(ctx, i+1, userSpec)
+		// 部分失败时仍保留已创建资源的输出
+		results[i] = userOutput
 		if err != nil {
+			logger.Error(i18n.T("处理用户时出错"), logging.Err(err), logging.Duration(started))
+			if ctx.Err() == nil {
+				recordUserError(recorder, userSpec.Username, result.ActionCreate, started, err)
+			}
 			return err
 		}
+		// 有资源失败的用户不标记为完成，--resume 时会重新处理
+		if !recorder.HasFailures() {
+			if err := cp.Complete(userSpec.Username, userOutput); err != nil {
+				recordUserError(recorder, userOutput.Username, result.ActionCreate, started, err)
+				return err
+			}
+		}
 
-		// 将输出结果添加到列表
+		logger.Info(i18n.T("用户处理完成"), logging.Duration(started))
+		return nil
+	})
+
+	summary := result.NewSummary(recorders...)
+	if ctx.Err() != nil {
+		var remaining []string
+		for i, userOutput := range results {
+			if userOutput == nil {
+				remaining = append(remaining, userConfig.Users[i].Username)
+			}
+		}
+		reportCancelled(remaining)
+		_ = reportSummary(ctx, summary)
+		if cp != nil {
+			slog.Warn(i18n.T("创建中断，可使用 --resume 继续"), "checkpoint", cp.Path())
+		}
+		return ctx.Err()
+	}
+
+	var userOutputs []types.UserOutput
+	for _, userOutput := range results {
 		if userOutput != nil {
 			userOutputs = append(userOutputs, *userOutput)
 		}
-
-		log.Printf("\n✓ 用户 '%s' 处理完成\n\n", userSpec.Username)
 	}
 
-	log.Println("========================================")
-	log.Println("✓ 批量创建完成")
-	log.Println("========================================")
+	slog.Info(i18n.T("批量创建完成"), "users", len(userOutputs), logging.Duration(runStarted))
+	summaryErr := reportSummary(ctx, summary)
+	if summaryErr != nil && cp != nil {
+		slog.Warn(i18n.T("部分资源创建失败，可使用 --resume 重试"), "checkpoint", cp.Path())
+	}
 
-	// 如果指定了输出文件，保存结果
-	if cfg.OutputFile != "" {
+	// 所有输出目标都由同一个 OutputConfig 生成
+	if len(targets) > 0 {
 		// 从 GitLabHost 解析 endpoint、scheme、host 和 port
 		endpoint, scheme, host, port := parseGitLabHostURL(cfg.GitLabHost)
 
@@ -205,86 +440,173 @@ func runUserCreate(cfg *config.CLIConfig) error {
 			Host:     host,
 			Port:     port,
 			SSH:      sshConfig,
+			RunID:    runID,
 			Users:    userOutputs,
 		}
 
-		// 如果指定了模板文件，使用模板渲染
-		if cfg.TemplateFile != "" {
-			log.Printf("\n使用模板渲染输出: %s\n", cfg.TemplateFile)
-			log.Printf("保存结果到文件: %s\n", cfg.OutputFile)
-			if err := template.SaveTemplateOutput(cfg.TemplateFile, cfg.OutputFile, output); err != nil {
-				return err
-			}
-			log.Printf("✓ 使用模板渲染完成，结果已保存到: %s\n", cfg.OutputFile)
-		} else {
-			// 使用默认 YAML 格式
-			log.Printf("\n保存结果到文件: %s\n", cfg.OutputFile)
-			if err := config.SaveOutput(cfg.OutputFile, output); err != nil {
-				return err
+		slog.Info(i18n.T("保存结果"), "targets", len(targets))
+		if err := saveOutputs(targets, output, template.Options{Strict: cfg.StrictTemplate}, enc); err != nil {
+			return err
+		}
+	}
+
+	return summaryErr
+}
+
+// resolveRunID 确定本次创建使用的运行 ID：断点续跑时沿用断点文件中的 ID，
+// 其次使用 --run-id，否则根据毫秒时间戳和 --suffix 生成
+func resolveRunID(cfg *config.CLIConfig) (string, error) {
+	runID, err := runIDFlag(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.ResumeFile != "" {
+		cp, err := checkpoint.Load(cfg.ResumeFile)
+		if err != nil {
+			return "", err
+		}
+		if previous := cp.RunID(); previous != "" {
+			if runID != "" && runID != previous {
+				return "", fmt.Errorf(i18n.T("--run-id %[1]s 与 %[3]s 中记录的运行 ID %[2]s 不一致"), runID, previous, cfg.ResumeFile)
 			}
-			log.Printf("✓ 结果已保存到: %s\n", cfg.OutputFile)
+			return previous, nil
 		}
 	}
 
-	return nil
+	if runID != "" {
+		return runID, nil
+	}
+	return utils.NewRunID(cfg.NameSuffix), nil
+}
+
+// runIDFlag 返回规范化后的 --run-id 参数，未指定时返回空字符串
+func runIDFlag(cfg *config.CLIConfig) (string, error) {
+	runID := utils.NormalizeRunID(cfg.RunID)
+	if cfg.RunID != "" && runID == "" {
+		return "", fmt.Errorf(i18n.T("无效的 --run-id %q：只能包含字母、数字、'_' 和 '-'"), cfg.RunID)
+	}
+	return runID, nil
+}
+
+// openCheckpoint 根据 --resume 或 --checkpoint 参数打开断点文件，两者都未指定时返回 nil
+func openCheckpoint(cfg *config.CLIConfig, runID string) (*checkpoint.Checkpoint, error) {
+	if cfg.ResumeFile != "" {
+		cp, err := checkpoint.Load(cfg.ResumeFile)
+		if err != nil {
+			return nil, err
+		}
+		if cp.ConfigFile() != "" && cp.ConfigFile() != cfg.ConfigFile {
+			slog.Warn(i18n.T("断点文件记录的配置文件与当前不同"), "recorded", cp.ConfigFile(), "current", cfg.ConfigFile)
+		}
+		slog.Info(i18n.T("从断点文件恢复"), "checkpoint", cfg.ResumeFile)
+		return cp, nil
+	}
+
+	if cfg.CheckpointFile != "" {
+		slog.Info(i18n.T("断点文件"), "checkpoint", cfg.CheckpointFile)
+		return checkpoint.New(cfg.CheckpointFile, cfg.ConfigFile, runID), nil
+	}
+
+	return nil, nil
 }
 
 // runUserCleanup 执行用户清理命令
-func runUserCleanup(cfg *config.CLIConfig) error {
-	gitlabClient, err := initializeClient(cfg)
+func runUserCleanup(ctx context.Context, cfg *config.CLIConfig) error {
+	runID, err := runIDFlag(cfg)
+	if err != nil {
+		return err
+	}
+
+	gitlabClient, err := initializeAuditedClient(ctx, cfg, runID)
 	if err != nil {
 		return err
 	}
 	defer gitlabClient.CloseIdleConnections()
+	defer logRetrySummary(gitlabClient)
+	runStarted := time.Now()
 
-	userConfig, err := config.Load(cfg.ConfigFile)
+	userConfig, err := config.Load(cfg.ConfigFile, cfg.Format)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("\n找到 %d 个用户配置\n", len(userConfig.Users))
+	slog.Info(i18n.T("找到用户配置"), "count", len(userConfig.Users))
 	if cfg.DaysOld > 0 {
-		log.Printf("只删除创建日期超过 %d 天的用户\n\n", cfg.DaysOld)
+		slog.Info(i18n.T("只删除创建日期超过指定天数的用户"), "days_old", cfg.DaysOld)
 	} else {
-		log.Printf("将删除所有匹配的用户（不检查创建时间）\n\n")
+		slog.Info(i18n.T("将删除所有匹配的用户（不检查创建时间）"))
 	}
 
-	proc := &processor.ResourceProcessor{Client: gitlabClient}
+	if runID != "" {
+		slog.Info(i18n.T("清理运行创建的资源"), "run_id", runID)
+		tracing.FromContext(ctx).SetAttributes(slog.String("run_id", runID))
+	}
 
-	processedCount := 0
-	skippedCount := 0
+	stream, err := openEvents(cfg, runID, result.ActionDelete)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	proc := &processor.ResourceProcessor{
+		Client:       gitlabClient,
+		Namer:        naming.New(runID),
+		SharedGroups: processor.SharedGroupPaths(userConfig.Users),
+		WaitTimeout:  cfg.WaitTimeout,
+		Events:       stream,
+	}
 
+	var processedCount, skippedCount atomic.Int32
+	recorders := make([]*result.Recorder, len(userConfig.Users))
 	for i, userSpec := range userConfig.Users {
-		log.Printf("==========================================\n")
-		log.Printf("处理 [%d/%d]: %s\n", i+1, len(userConfig.Users), userSpec.Username)
-		log.Printf("==========================================\n")
+		recorders[i] = result.NewRecorder(userSpec.Username)
+	}
 
-		deleted, err := proc.ProcessUserCleanup(userSpec, cfg.DaysOld)
+	errs := utils.RunParallel(len(userConfig.Users), cfg.Concurrency, false, func(i int) error {
+		userSpec := userConfig.Users[i]
+		logger := userLogger(userSpec.Username, result.ActionDelete)
+		started := time.Now()
+
+		logger.Info(i18n.T("处理用户"), "index", i+1, "total", len(userConfig.Users))
+
+		deleted, err := proc.ForUser(logger, recorders[i]).ProcessUserCleanup(ctx, i+1, userSpec, cfg.DaysOld)
 		if err != nil {
-			log.Printf("  ⚠ 处理用户 %s 时出错: %v\n", userSpec.Username, err)
-			continue
+			logger.Error(i18n.T("处理用户时出错"), logging.Err(err), logging.Duration(started))
+			if ctx.Err() == nil {
+				recordUserError(recorders[i], userSpec.Username, result.ActionDelete, started, err)
+			}
+			return err
 		}
 
 		if deleted {
-			processedCount++
+			processedCount.Add(1)
 		} else {
-			skippedCount++
+			skippedCount.Add(1)
 		}
+		return nil
+	})
+
+	summary := result.NewSummary(recorders...)
+	if ctx.Err() != nil {
+		reportCancelled(cancelledUsers(errs, func(i int) string { return userConfig.Users[i].Username }))
+		_ = reportSummary(ctx, summary)
+		return ctx.Err()
 	}
 
-	log.Println("========================================")
-	log.Printf("✓ 批量清理完成 (已删除: %d, 已跳过: %d)\n", processedCount, skippedCount)
-	log.Println("========================================")
-	return nil
+	slog.Info(i18n.T("批量清理完成"), "deleted", processedCount.Load(), "skipped", skippedCount.Load(), logging.Duration(runStarted))
+	return reportSummary(ctx, summary)
 }
 
 // runUserDelete 执行用户删除命令
-func runUserDelete(cfg *config.CLIConfig, usernames string) error {
-	gitlabClient, err := initializeClient(cfg)
+func runUserDelete(ctx context.Context, cfg *config.CLIConfig, usernames string) error {
+	gitlabClient, err := initializeAuditedClient(ctx, cfg, "")
 	if err != nil {
 		return err
 	}
 	defer gitlabClient.CloseIdleConnections()
+	defer logRetrySummary(gitlabClient)
+	runStarted := time.Now()
 
 	// 解析用户名列表（以逗号分隔）
 	usernameList := strings.Split(usernames, ",")
@@ -293,29 +615,47 @@ func runUserDelete(cfg *config.CLIConfig, usernames string) error {
 		usernameList[i] = strings.TrimSpace(username)
 	}
 
-	log.Printf("\n准备删除 %d 个用户\n\n", len(usernameList))
+	slog.Info(i18n.T("准备删除用户"), "count", len(usernameList))
+
+	stream, err := openEvents(cfg, "", result.ActionDelete)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
 
-	proc := &processor.ResourceProcessor{Client: gitlabClient}
+	proc := &processor.ResourceProcessor{Client: gitlabClient, WaitTimeout: cfg.WaitTimeout, Events: stream}
 
+	var recorders []*result.Recorder
 	for i, username := range usernameList {
 		if username == "" {
 			continue
 		}
-
-		log.Printf("==========================================\n")
-		log.Printf("处理 [%d/%d]: %s\n", i+1, len(usernameList), username)
-		log.Printf("==========================================\n")
-
-		if err := proc.ProcessUserDelete(username); err != nil {
-			log.Printf("  ⚠ 删除用户 %s 时出错: %v\n", username, err)
+		if ctx.Err() != nil {
+			reportCancelled(usernameList[i:])
+			_ = reportSummary(ctx, result.NewSummary(recorders...))
+			return ctx.Err()
+		}
+		recorder := result.NewRecorder(username)
+		recorders = append(recorders, recorder)
+		started := time.Now()
+
+		logger := userLogger(username, result.ActionDelete)
+		logger.Info(i18n.T("处理用户"), "index", i+1, "total", len(usernameList))
+
+		if err := proc.ForUser(logger, recorder).ProcessUserDelete(ctx, username); err != nil {
+			logger.Error(i18n.T("删除用户时出错"), logging.Err(err), logging.Duration(started))
+			if ctx.Err() != nil {
+				reportCancelled(usernameList[i:])
+				_ = reportSummary(ctx, result.NewSummary(recorders...))
+				return ctx.Err()
+			}
+			recordUserError(recorder, username, result.ActionDelete, started, err)
 			continue
 		}
 	}
 
-	log.Println("========================================")
-	log.Println("✓ 批量删除完成")
-	log.Println("========================================")
-	return nil
+	slog.Info(i18n.T("批量删除完成"), logging.Duration(runStarted))
+	return reportSummary(ctx, result.NewSummary(recorders...))
 }
 
 // buildUserListCommand 构建用户列表命令
@@ -324,21 +664,22 @@ func buildUserListCommand(cfg *config.CLIConfig) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "列出所有用户或搜索特定前缀的用户",
-		Long: `列出 GitLab 上的用户。
+		Short: i18n.T("列出所有用户或搜索特定前缀的用户"),
+		Long: i18n.T(`列出 GitLab 上的用户。
 可以使用 --prefix 参数搜索特定前缀的用户。
 
 示例:
   gitlab-cli user list
-  gitlab-cli user list --prefix tektoncd`,
+  gitlab-cli user list --prefix tektoncd`),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUserList(cfg, searchPrefix)
+			return runUserList(cmd.Context(), cfg, searchPrefix, cmd.OutOrStdout())
 		},
 	}
 
-	cmd.Flags().StringVar(&searchPrefix, "prefix", "", "搜索用户名前缀")
-	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", "GitLab 主机地址")
-	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", "GitLab Personal Access Token")
+	cmd.Flags().StringVar(&searchPrefix, "prefix", "", i18n.T("搜索用户名前缀"))
+	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", i18n.T("GitLab 主机地址"))
+	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", i18n.T("GitLab 个人访问令牌（Personal Access Token）"))
+	addRetryFlags(cmd, cfg)
 
 	return cmd
 }
@@ -350,8 +691,9 @@ func buildUserDeleteByPrefixCommand(cfg *config.CLIConfig) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "delete-by-prefix",
-		Short: "删除所有匹配前缀的用户及其资源",
-		Long: `根据用户名前缀批量删除用户及其所有资源（项目和组）。
+		Short: i18n.T("删除所有匹配前缀的用户及其资源"),
+		Long: i18n.T(`根据用户名前缀批量删除用户及其所有资源（项目和组）。
+指定 --run-id 时只删除该次运行创建的用户，此时 --prefix 可省略。
 支持 --dry-run 模式预览将要删除的用户。
 默认只删除创建日期超过2天的用户，可通过 --days-old 参数调整。
 
@@ -359,62 +701,92 @@ func buildUserDeleteByPrefixCommand(cfg *config.CLIConfig) *cobra.Command {
   gitlab-cli user delete-by-prefix --prefix tektoncd --dry-run            # 预览2天前创建的用户
   gitlab-cli user delete-by-prefix --prefix tektoncd                      # 删除2天前创建的用户
   gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 7         # 删除7天前创建的用户
-  gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 0         # 删除所有匹配前缀的用户`,
+  gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 0         # 删除所有匹配前缀的用户
+  gitlab-cli user delete-by-prefix --run-id 20251030150000123-a1b2 --days-old 0  # 删除指定运行创建的所有用户`),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUserDeleteByPrefix(cfg, prefix, dryRun)
+			if prefix == "" && cfg.RunID == "" {
+				return errors.New(i18n.T("必须指定 --prefix 或 --run-id"))
+			}
+			// 事件输出到标准输出时，待删除的用户列表改为输出到标准错误
+			w := cmd.OutOrStdout()
+			if cfg.EventsFile == utils.StdioPath || cfg.TraceFile == utils.StdioPath {
+				w = logging.Console()
+			}
+			return instrumentRun(cmd, cfg, result.ActionDelete, func(ctx context.Context) error {
+				return runUserDeleteByPrefix(ctx, cfg, prefix, dryRun, w)
+			})
 		},
 	}
 
-	cmd.Flags().StringVar(&prefix, "prefix", "", "要删除的用户名前缀")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "只显示将要删除的用户，不实际删除")
-	cmd.Flags().IntVar(&cfg.DaysOld, "days-old", 2, "只删除创建日期超过指定天数的用户（0表示删除所有用户）")
-	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", "GitLab 主机地址")
-	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", "GitLab Personal Access Token")
-	_ = cmd.MarkFlagRequired("prefix")
+	cmd.Flags().StringVar(&prefix, "prefix", "", i18n.T("要删除的用户名前缀"))
+	cmd.Flags().StringVar(&cfg.RunID, "run-id", "", i18n.T("只删除该运行 ID 创建的用户"))
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, i18n.T("只显示将要删除的用户，不实际删除"))
+	cmd.Flags().IntVar(&cfg.DaysOld, "days-old", 2, i18n.T("只删除创建日期超过指定天数的用户（0表示删除所有用户）"))
+	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", i18n.T("GitLab 主机地址"))
+	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", i18n.T("GitLab 个人访问令牌（Personal Access Token）"))
+	addRetryFlags(cmd, cfg)
+	addConcurrencyFlags(cmd, cfg)
+	addWaitFlags(cmd, cfg)
+	addEventsFlag(cmd, cfg)
+	addTraceFlags(cmd, cfg)
+	addMetricsFlag(cmd, cfg)
+	addReportFlag(cmd, cfg)
 
 	return cmd
 }
 
-// runUserList 执行用户列表命令
-func runUserList(cfg *config.CLIConfig, searchPrefix string) error {
-	gitlabClient, err := initializeClient(cfg)
+// runUserList 执行用户列表命令，用户列表输出到 w，进度输出到日志
+func runUserList(ctx context.Context, cfg *config.CLIConfig, searchPrefix string, w io.Writer) error {
+	gitlabClient, err := initializeClient(ctx, cfg)
 	if err != nil {
 		return err
 	}
 	defer gitlabClient.CloseIdleConnections()
+	defer logRetrySummary(gitlabClient)
 
-	log.Printf("\n正在获取用户列表...\n")
-	if searchPrefix != "" {
-		log.Printf("搜索前缀: %s\n", searchPrefix)
-	}
+	slog.Info(i18n.T("正在获取用户列表"), "prefix", searchPrefix)
 
-	users, err := gitlabClient.ListAllUsers(searchPrefix)
+	users, err := gitlabClient.ListAllUsers(ctx, searchPrefix)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("\n找到 %d 个用户:\n", len(users))
-	log.Println("========================================")
-	for i, user := range users {
-		log.Printf("[%d] ID: %d | 用户名: %s | 姓名: %s | 邮箱: %s | 管理员: %v\n",
-			i+1, user.ID, user.Username, user.Name, user.Email, user.IsAdmin)
+	slog.Info(i18n.T("找到的用户数"), "count", len(users))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tUSERNAME\tNAME\tEMAIL\tADMIN")
+	for _, user := range users {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%v\n", user.ID, user.Username, user.Name, user.Email, user.IsAdmin)
 	}
-	log.Println("========================================")
+	tw.Flush()
 
 	return nil
 }
 
-// runUserDeleteByPrefix 执行按前缀批量删除用户命令
-func runUserDeleteByPrefix(cfg *config.CLIConfig, prefix string, dryRun bool) error {
-	gitlabClient, err := initializeClient(cfg)
+// runUserDeleteByPrefix 执行按前缀批量删除用户命令，待删除的用户列表输出到 w
+func runUserDeleteByPrefix(ctx context.Context, cfg *config.CLIConfig, prefix string, dryRun bool, w io.Writer) error {
+	runID, err := runIDFlag(cfg)
 	if err != nil {
 		return err
 	}
-	defer gitlabClient.CloseIdleConnections()
 
-	log.Printf("\n正在搜索用户名以 '%s' 开头的用户...\n", prefix)
+	gitlabClient, err := initializeAuditedClient(ctx, cfg, runID)
+	if err != nil {
+		return err
+	}
+	defer gitlabClient.CloseIdleConnections()
+	defer logRetrySummary(gitlabClient)
+	runStarted := time.Now()
+
+	// 指定运行 ID 时按运行 ID 搜索，前缀只用于进一步过滤
+	search := prefix
+	if runID != "" {
+		search = runID
+		slog.Info(i18n.T("正在搜索运行创建的用户"), "run_id", runID)
+	} else {
+		slog.Info(i18n.T("正在搜索用户名以前缀开头的用户"), "prefix", prefix)
+	}
 
-	users, err := gitlabClient.ListAllUsers(prefix)
+	users, err := gitlabClient.ListAllUsers(ctx, search)
 	if err != nil {
 		return err
 	}
@@ -422,23 +794,27 @@ func runUserDeleteByPrefix(cfg *config.CLIConfig, prefix string, dryRun bool) er
 	// 过滤出真正以指定前缀开头的用户（GitLab 的搜索可能返回包含该字符串的所有用户）
 	var matchedUsers []*gitlab.User
 	for _, user := range users {
-		if strings.HasPrefix(user.Username, prefix) {
+		if strings.HasPrefix(user.Username, prefix) && (runID == "" || utils.HasRunID(user.Username, runID)) {
 			matchedUsers = append(matchedUsers, user)
 		}
 	}
 
 	if len(matchedUsers) == 0 {
-		log.Printf("\n未找到以 '%s' 开头的用户\n", prefix)
+		if runID != "" {
+			slog.Info(i18n.T("未找到运行创建的用户"), "run_id", runID)
+		} else {
+			slog.Info(i18n.T("未找到以前缀开头的用户"), "prefix", prefix)
+		}
 		return nil
 	}
 
 	// 根据创建日期过滤用户
 	var usersToDelete []*gitlab.User
 	if cfg.DaysOld > 0 {
-		log.Printf("\n按创建日期过滤（只处理 %d 天前创建的用户）...\n", cfg.DaysOld)
+		slog.Info(i18n.T("按创建日期过滤"), "days_old", cfg.DaysOld)
 		for _, user := range matchedUsers {
 			if user.CreatedAt == nil {
-				log.Printf("  [跳过] %s - 无法获取创建时间\n", user.Username)
+				slog.Info(i18n.T("跳过用户：无法获取创建时间"), "username", user.Username)
 				continue
 			}
 
@@ -446,8 +822,7 @@ func runUserDeleteByPrefix(cfg *config.CLIConfig, prefix string, dryRun bool) er
 			if daysSinceCreation >= cfg.DaysOld {
 				usersToDelete = append(usersToDelete, user)
 			} else {
-				log.Printf("  [跳过] %s - 创建于 %d 天前（未超过 %d 天）\n",
-					user.Username, daysSinceCreation, cfg.DaysOld)
+				slog.Info(i18n.T("跳过用户：创建时间未超过指定天数"), "username", user.Username, "days", daysSinceCreation, "days_old", cfg.DaysOld)
 			}
 		}
 	} else {
@@ -455,75 +830,176 @@ func runUserDeleteByPrefix(cfg *config.CLIConfig, prefix string, dryRun bool) er
 	}
 
 	if len(usersToDelete) == 0 {
-		log.Printf("\n没有符合条件的用户需要删除\n")
+		slog.Info(i18n.T("没有符合条件的用户需要删除"))
 		return nil
 	}
 
-	log.Printf("\n找到 %d 个符合条件的用户:\n", len(usersToDelete))
-	log.Println("========================================")
-	for i, user := range usersToDelete {
-		createdInfo := ""
+	slog.Info(i18n.T("找到符合条件的用户"), "count", len(usersToDelete), "filtered", len(matchedUsers)-len(usersToDelete))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tUSERNAME\tNAME\tEMAIL\tCREATED")
+	for _, user := range usersToDelete {
+		created := ""
 		if user.CreatedAt != nil {
 			daysSinceCreation := int(time.Since(*user.CreatedAt).Hours() / 24)
-			createdInfo = fmt.Sprintf(" | 创建于: %s (%d 天前)",
-				user.CreatedAt.Format("2006-01-02"), daysSinceCreation)
+			created = fmt.Sprintf(i18n.T("%s (%d 天前)"), user.CreatedAt.Format("2006-01-02"), daysSinceCreation)
 		}
-		log.Printf("[%d] ID: %d | 用户名: %s | 姓名: %s | 邮箱: %s%s\n",
-			i+1, user.ID, user.Username, user.Name, user.Email, createdInfo)
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", user.ID, user.Username, user.Name, user.Email, created)
 	}
-	log.Println("========================================")
+	tw.Flush()
 
 	if dryRun {
-		log.Printf("\n[DRY-RUN] 以上 %d 个用户将被删除（当前为预览模式）\n", len(usersToDelete))
-		if cfg.DaysOld > 0 {
-			log.Printf("[DRY-RUN] 已过滤掉 %d 个不满足时间条件的用户\n", len(matchedUsers)-len(usersToDelete))
-		}
+		slog.Info(i18n.T("[DRY-RUN] 以上用户将被删除（当前为预览模式）"), "count", len(usersToDelete))
 		return nil
 	}
 
-	log.Printf("\n准备删除 %d 个用户及其所有资源...\n", len(usersToDelete))
-	if cfg.DaysOld > 0 {
-		log.Printf("（已跳过 %d 个创建时间未超过 %d 天的用户）\n", len(matchedUsers)-len(usersToDelete), cfg.DaysOld)
+	slog.Info(i18n.T("准备删除用户及其所有资源"), "count", len(usersToDelete))
+
+	stream, err := openEvents(cfg, runID, result.ActionDelete)
+	if err != nil {
+		return err
 	}
+	defer stream.Close()
 
-	proc := &processor.ResourceProcessor{Client: gitlabClient}
+	proc := &processor.ResourceProcessor{Client: gitlabClient, WaitTimeout: cfg.WaitTimeout, Events: stream}
 
+	recorders := make([]*result.Recorder, len(usersToDelete))
 	for i, user := range usersToDelete {
-		log.Printf("==========================================\n")
-		log.Printf("处理 [%d/%d]: %s (ID: %d)\n", i+1, len(usersToDelete), user.Username, user.ID)
-		log.Printf("==========================================\n")
+		recorders[i] = result.NewRecorder(user.Username)
+	}
 
-		if err := proc.ProcessUserDelete(user.Username); err != nil {
-			log.Printf("  ⚠ 删除用户 %s 时出错: %v\n", user.Username, err)
-			continue
+	errs := utils.RunParallel(len(usersToDelete), cfg.Concurrency, false, func(i int) error {
+		user := usersToDelete[i]
+		logger := userLogger(user.Username, result.ActionDelete)
+		started := time.Now()
+
+		logger.Info(i18n.T("处理用户"), "id", user.ID, "index", i+1, "total", len(usersToDelete))
+
+		if err := proc.ForUser(logger, recorders[i]).ProcessUserDelete(ctx, user.Username); err != nil {
+			logger.Error(i18n.T("删除用户时出错"), logging.Err(err), logging.Duration(started))
+			if ctx.Err() == nil {
+				recordUserError(recorders[i], user.Username, result.ActionDelete, started, err)
+			}
+			return err
 		}
+		return nil
+	})
+
+	summary := result.NewSummary(recorders...)
+	if ctx.Err() != nil {
+		reportCancelled(cancelledUsers(errs, func(i int) string { return usersToDelete[i].Username }))
+		_ = reportSummary(ctx, summary)
+		return ctx.Err()
 	}
 
-	log.Println("========================================")
-	log.Printf("✓ 批量删除完成（共处理 %d 个用户）\n", len(usersToDelete))
-	log.Println("========================================")
-	return nil
+	slog.Info(i18n.T("批量删除完成"), "users", len(usersToDelete), logging.Duration(runStarted))
+	return reportSummary(ctx, summary)
 }
 
 // initializeClient 初始化并验证 GitLab 客户端
-func initializeClient(cfg *config.CLIConfig) (*client.GitLabClient, error) {
+func initializeClient(ctx context.Context, cfg *config.CLIConfig, opts ...client.Option) (*client.GitLabClient, error) {
 	if err := config.LoadGitLabCredentials(cfg); err != nil {
 		return nil, err
 	}
-
-	gitlabClient, err := client.NewGitLabClient(cfg.GitLabHost, cfg.GitLabToken)
+	redact.Add(cfg.GitLabToken)
+
+	opts = append([]client.Option{
+		client.WithRateLimit(cfg.RateLimit),
+		client.WithRetryPolicy(client.RetryPolicy{
+			MaxAttempts: cfg.RetryMaxAttempts,
+			BaseDelay:   cfg.RetryBaseDelay,
+			MaxDelay:    cfg.RetryMaxDelay,
+		}),
+	}, opts...)
+	gitlabClient, err := client.NewGitLabClient(cfg.GitLabHost, cfg.GitLabToken, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("检查 GitLab 连接和权限...")
-	if err := gitlabClient.CheckAuth(); err != nil {
+	slog.Info(i18n.T("检查 GitLab 连接和权限"), "host", cfg.GitLabHost)
+	if err := gitlabClient.CheckAuth(ctx); err != nil {
 		return nil, err
 	}
 
 	return gitlabClient, nil
 }
 
+// initializeAuditedClient 初始化 GitLab 客户端，并把修改性 API 调用连同 runID 写入审计日志
+func initializeAuditedClient(ctx context.Context, cfg *config.CLIConfig, runID string) (*client.GitLabClient, error) {
+	auditOpt, err := auditOption(cfg, runID)
+	if err != nil {
+		return nil, err
+	}
+	return initializeClient(ctx, cfg, auditOpt)
+}
+
+// userLogger 返回处理单个用户时使用的日志器，每行日志都带有用户名和操作字段，
+// 并发处理时据此区分归属
+func userLogger(username string, action result.Action) *slog.Logger {
+	return slog.With(logging.KeyUser, username, logging.KeyAction, string(action))
+}
+
+// cancelledUsers 返回因取消而未处理完成的用户名
+func cancelledUsers(errs []error, username func(i int) string) []string {
+	var remaining []string
+	for i, err := range errs {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			remaining = append(remaining, username(i))
+		}
+	}
+	return remaining
+}
+
+// reportCancelled 在收到中断信号后列出尚未处理完成的用户
+func reportCancelled(remaining []string) {
+	slog.Warn(i18n.T("操作已取消，部分用户未处理完成"), "count", len(remaining), "users", remaining)
+}
+
+// logRetrySummary 输出本次运行中 API 调用的重试统计
+func logRetrySummary(gitlabClient *client.GitLabClient) {
+	stats := gitlabClient.RetryStats()
+
+	byOperation := stats.ByOperation()
+	operations := make([]string, 0, len(byOperation))
+	for operation := range byOperation {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	counts := make([]any, 0, len(operations))
+	for _, operation := range operations {
+		counts = append(counts, slog.Int(operation, byOperation[operation]))
+	}
+	slog.Info(i18n.T("API 重试次数"), "total", stats.Total(), slog.Group("by_operation", counts...))
+}
+
+// addRetryFlags 注册 API 瞬时错误重试相关的参数
+func addRetryFlags(cmd *cobra.Command, cfg *config.CLIConfig) {
+	defaults := client.DefaultRetryPolicy()
+	cmd.Flags().IntVar(&cfg.RetryMaxAttempts, "retry-max", defaults.MaxAttempts, i18n.T("瞬时 API 错误（5xx、429、409）的最大尝试次数，1 表示不重试"))
+	cmd.Flags().DurationVar(&cfg.RetryBaseDelay, "retry-base-delay", defaults.BaseDelay, i18n.T("第一次重试前的退避时间，之后每次翻倍并带随机抖动"))
+	cmd.Flags().DurationVar(&cfg.RetryMaxDelay, "retry-max-delay", defaults.MaxDelay, i18n.T("重试退避时间的上限（服务端返回的 Retry-After 优先）"))
+}
+
+// addFormatFlag 注册配置和输出文件格式参数
+func addFormatFlag(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().StringVar(&cfg.Format, "format", "", i18n.T("配置和输出文件的格式: yaml、json 或 toml（默认根据扩展名判断，- 和其他扩展名按 yaml）"))
+}
+
+// addEncryptFlags 注册输出文件加密参数
+func addEncryptFlags(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().StringArrayVar(&cfg.EncryptTo, "encrypt-to", nil, i18n.Tf("使用 age 加密输出文件：age1 开头的公钥，可重复指定；passphrase 表示使用 %s 中的口令", encrypt.PassphraseEnv))
+}
+
+// addWaitFlags 注册等待 GitLab 异步删除相关的参数
+func addWaitFlags(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().DurationVar(&cfg.WaitTimeout, "wait-timeout", 5*time.Minute, i18n.T("每次等待 GitLab 完成异步删除的最长时间"))
+}
+
+// addConcurrencyFlags 注册并发处理相关的参数
+func addConcurrencyFlags(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().IntVar(&cfg.Concurrency, "concurrency", 1, i18n.T("并发处理的用户数"))
+	cmd.Flags().Float64Var(&cfg.RateLimit, "rate-limit", 0, i18n.T("所有并发任务共享的每秒最大 API 请求数（0 表示不限制）"))
+}
+
 // parseGitLabSSHEndpoint extracts endpoint, host, and port from the SSH URL string
 func parseGitLabSSHEndpoint(rawEndpoint string) (endpoint, host string, port int) {
 	// trimmedEndpoint stores the SSH endpoint without trailing slash or spaces
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/events"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/utils"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// addEventsFlag 注册事件输出参数
+func addEventsFlag(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().StringVar(&cfg.EventsFile, "events-file", "", i18n.T("以 NDJSON 格式追加写入每个资源操作事件的文件（- 表示标准输出）"))
+}
+
+// openEvents 打开 --events-file 并输出本次运行的 start 事件，未指定时返回 nil
+func openEvents(cfg *config.CLIConfig, runID string, action result.Action) (*events.Stream, error) {
+	if cfg.EventsFile == "" {
+		return nil, nil
+	}
+	stream, err := events.Open(cfg.EventsFile, runID)
+	if err != nil {
+		return nil, err
+	}
+	stream.Emit(events.Event{Type: events.TypeStart, Action: action, Kind: events.KindRun, Path: cfg.ConfigFile})
+	return stream, nil
+}
+
+// checkEventsTarget 检查事件和输出结果没有同时写到标准输出
+func checkEventsTarget(cfg *config.CLIConfig, targets []types.OutputTarget) error {
+	if cfg.EventsFile != utils.StdioPath {
+		return nil
+	}
+	for _, target := range targets {
+		if target.Path == utils.StdioPath {
+			return errors.New(i18n.T("--events-file - 和 --output - 不能同时输出到标准输出"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/metrics"
+	"gitlab-cli-sdk/internal/result"
+)
+
+const (
+	// ExitPartialFailure is the exit code when some resource operations failed.
+	ExitPartialFailure = 2
+	// ExitTotalFailure is the exit code when resource operations failed and none succeeded.
+	ExitTotalFailure = 3
+)
+
+// ExitError 携带进程退出码的错误；其他错误以退出码 1 结束
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error implements error.
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// reportSummary 输出结果汇总，并根据结果返回对应退出码的错误；全部成功时返回 nil。
+// 汇总表面向用户输出到标准错误，json 日志格式下只记录各项计数，失败详情已在处理时记录。
+// context 中有指标 Registry 时同时统计资源操作并输出 API 调用的合计；指定了 --report 时汇总留给运行报告
+func reportSummary(ctx context.Context, summary *result.Summary) error {
+	keepSummary(ctx, summary)
+	if !logging.IsJSON() {
+		summary.Print(logging.Console())
+	}
+	succeeded, failed, skipped := summary.Counts()
+	slog.Info(i18n.T("结果汇总"), "succeeded", succeeded, "failed", failed, "skipped", skipped)
+
+	registry := metrics.FromContext(ctx)
+	registry.ObserveSummary(summary)
+	reportMetrics(registry)
+
+	switch summary.Outcome() {
+	case result.OutcomePartialFailure:
+		return &ExitError{Code: ExitPartialFailure, Err: fmt.Errorf(i18n.T("%d 个资源操作失败"), failed)}
+	case result.OutcomeTotalFailure:
+		return &ExitError{Code: ExitTotalFailure, Err: fmt.Errorf(i18n.T("全部 %d 个资源操作失败"), failed)}
+	}
+	return nil
+}
+
+// recordUserError 把未被处理器记录的用户级错误（例如断点文件写入失败）计入结果
+func recordUserError(recorder *result.Recorder, username string, action result.Action, started time.Time, err error) {
+	if recorder.HasFailures() {
+		return
+	}
+	recorder.Failed(result.KindUser, username, action, started, err)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"gitlab-cli-sdk/internal/encrypt"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// buildKeygenCommand 构建生成 age 密钥对的命令
+func buildKeygenCommand() *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: i18n.T("生成加密输出文件使用的 age 密钥对"),
+		Long: i18n.T(`生成 X25519 密钥对，与 age-keygen 兼容。
+私钥写入 --output 指定的文件（权限 0600），公钥打印到标准错误，用于 --encrypt-to。
+
+示例:
+  gitlab-cli keygen -o key.txt
+  gitlab-cli user create -f config.yaml -o output.yaml --encrypt-to age1...
+  gitlab-cli render --identity key.txt --data output.yaml -t template.yaml`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKeygen(outputFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "output", "o", utils.StdioPath, i18n.T("私钥文件（- 表示标准输出）"))
+
+	return cmd
+}
+
+// runKeygen 执行密钥生成命令
+func runKeygen(outputFile string) error {
+	identity, recipient, err := encrypt.GenerateIdentity()
+	if err != nil {
+		return fmt.Errorf(i18n.T("生成密钥失败: %w"), err)
+	}
+	if err := utils.WriteFileAtomic(outputFile, []byte(identity), 0600); err != nil {
+		return fmt.Errorf(i18n.T("写入私钥文件失败: %w"), err)
+	}
+	fmt.Fprintf(logging.Console(), i18n.T("公钥: %s\n"), recipient)
+	return nil
+}
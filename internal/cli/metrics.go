@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/metrics"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/utils"
+)
+
+// addMetricsFlag 注册指标输出参数
+func addMetricsFlag(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().StringVar(&cfg.MetricsFile, "metrics-file", "", i18n.T("运行结束后以 Prometheus textfile 格式写入指标的文件（供 node_exporter textfile collector 收集）"))
+}
+
+// instrumentRun 记录整个运行的 trace 和指标并写出运行报告，见 traceRun、measureRun 和 reportRun
+func instrumentRun(cmd *cobra.Command, cfg *config.CLIConfig, action result.Action, run func(ctx context.Context) error) error {
+	return traceRun(cmd.Context(), cfg, action, func(ctx context.Context) error {
+		return measureRun(ctx, cfg, action, func(ctx context.Context) error {
+			return reportRun(ctx, cfg, cmd.CommandPath(), run)
+		})
+	})
+}
+
+// measureRun 在带有指标 Registry 的 context 中执行 run，结束后按 --metrics-file 写出指标。
+// 指标总是收集，API 调用和等待时间的合计显示在最终汇总中
+func measureRun(ctx context.Context, cfg *config.CLIConfig, action result.Action, run func(ctx context.Context) error) error {
+	registry := metrics.New()
+	started := time.Now()
+	err := run(metrics.NewContext(ctx, registry))
+	registry.ObserveRun(action, time.Since(started), err)
+
+	if cfg.MetricsFile != "" {
+		// 运行本身的错误优先，指标写入失败时只记录日志
+		if writeErr := writeMetrics(cfg.MetricsFile, registry); writeErr != nil {
+			if err == nil {
+				return writeErr
+			}
+			slog.Error(i18n.T("写入指标失败"), logging.Err(writeErr))
+		} else {
+			slog.Info(i18n.T("指标已写入"), "path", cfg.MetricsFile)
+		}
+	}
+	return err
+}
+
+// writeMetrics 原子地替换指标文件，textfile collector 不会读到写了一半的文件
+func writeMetrics(path string, registry *metrics.Registry) error {
+	var buf bytes.Buffer
+	if err := registry.WritePrometheus(&buf); err != nil {
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	if err := utils.WriteFileAtomic(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	return nil
+}
+
+// reportMetrics 在最终汇总中显示 API 调用、重试和等待 GitLab 完成删除的合计
+func reportMetrics(registry *metrics.Registry) {
+	if registry == nil {
+		return
+	}
+	totals := registry.Totals()
+	if !logging.IsJSON() {
+		writeMetricsTotals(logging.Console(), totals)
+	}
+	slog.Info(i18n.T("API 调用统计"), "api_calls", totals.APICalls, "retries", totals.Retries,
+		"verification_wait", totals.WaitTime.Round(time.Millisecond), "verification_timeouts", totals.Timeouts)
+}
+
+// writeMetricsTotals 输出一行合计，例如 api calls: 57 (2xx: 54, 4xx: 2, 5xx: 1), retries: 1, ...
+func writeMetricsTotals(w io.Writer, totals metrics.Totals) {
+	var classes []string
+	for _, class := range []string{"2xx", "3xx", "4xx", "5xx", "error"} {
+		if n := totals.ByClass[class]; n > 0 {
+			classes = append(classes, fmt.Sprintf("%s: %d", class, n))
+		}
+	}
+	fmt.Fprintf(w, "api calls: %d (%s), retries: %d, verification wait: %s, verification timeouts: %d\n",
+		totals.APICalls, strings.Join(classes, ", "), totals.Retries, totals.WaitTime.Round(time.Millisecond), totals.Timeouts)
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/encrypt"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/template"
+	"gitlab-cli-sdk/internal/utils"
+	"gitlab-cli-sdk/pkg/types"
+
+	"filippo.io/age"
+)
+
+// defaultOutputMode 是输出文件的默认权限。输出中包含密码和 Token，因此只允许当前用户读写
+const defaultOutputMode os.FileMode = 0600
+
+// outputEncryption holds the age recipients every output file is encrypted to.
+type outputEncryption struct {
+	recipients []age.Recipient
+	fields     bool // 原始结果只加密密码和 Token 字段
+}
+
+// newOutputEncryption 解析 --encrypt-to，未指定时返回 nil 表示不加密
+func newOutputEncryption(cfg *config.CLIConfig) (*outputEncryption, error) {
+	if len(cfg.EncryptTo) == 0 {
+		if cfg.EncryptFields {
+			return nil, errors.New(i18n.T("--encrypt-fields 需要同时指定 --encrypt-to"))
+		}
+		return nil, nil
+	}
+	recipients, err := encrypt.ParseRecipients(cfg.EncryptTo)
+	if err != nil {
+		return nil, err
+	}
+	return &outputEncryption{recipients: recipients, fields: cfg.EncryptFields}, nil
+}
+
+// outputTargets 汇总配置文件 outputs 和 --output 指定的输出目标。
+// 只写了路径的 --output 沿用 -t 或 --output-format，保持单个输出时的用法不变；
+// --redact 作用于除内置格式以外的所有目标
+func outputTargets(cfg *config.CLIConfig, configured []types.OutputTarget) ([]types.OutputTarget, error) {
+	targets := append([]types.OutputTarget(nil), configured...)
+
+	bare := 0
+	for _, spec := range cfg.OutputFiles {
+		target, err := config.ParseOutputTarget(spec)
+		if err != nil {
+			return nil, err
+		}
+		if target.Template == "" {
+			bare++
+			switch {
+			case cfg.OutputFormat != "":
+				target.Template = cfg.OutputFormat
+			case cfg.TemplateFile != "":
+				target.Template = cfg.TemplateFile
+			default:
+				target.Format = cfg.Format
+			}
+		}
+		targets = append(targets, target)
+	}
+	if cfg.Redact {
+		for i := range targets {
+			if !template.IsFormat(targets[i].Template) {
+				targets[i].Redact = true
+			}
+		}
+	}
+
+	if bare == 0 {
+		if cfg.OutputFormat != "" {
+			return nil, errors.New(i18n.T("--output-format 需要一个未指定模板的 --output（- 表示标准输出）"))
+		}
+		if cfg.TemplateFile != "" {
+			return nil, errors.New(i18n.T("--template 需要一个未指定模板的 --output（- 表示标准输出）"))
+		}
+	}
+	return targets, nil
+}
+
+// checkOutputTargets 在创建任何资源之前检查输出目标，避免创建完成后才发现无法保存结果
+func checkOutputTargets(targets []types.OutputTarget, hasSSH bool) error {
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if target.Path != utils.StdioPath {
+			if seen[target.Path] {
+				return fmt.Errorf(i18n.T("输出 %s 被重复指定"), target.Path)
+			}
+			seen[target.Path] = true
+		}
+		if target.Mode != "" {
+			if _, err := utils.ParseFileMode(target.Mode); err != nil {
+				return fmt.Errorf(i18n.T("输出 %s: %w"), target.Path, err)
+			}
+		}
+
+		switch {
+		case template.IsFormat(target.Template):
+			if err := template.CheckFormat(target.Template, hasSSH); err != nil {
+				return fmt.Errorf(i18n.T("输出 %s: %w"), target.Path, err)
+			}
+			if target.Redact {
+				return fmt.Errorf(i18n.T("输出 %s: 内置格式 %s 不支持 redact"), target.Path, target.Template)
+			}
+		case target.Template != "":
+			if _, err := os.Stat(target.Template); err != nil {
+				return fmt.Errorf(i18n.T("输出 %s: 模板 %w"), target.Path, err)
+			}
+		default:
+			if _, err := config.DetectFormat(target.Path, target.Format); err != nil {
+				return fmt.Errorf(i18n.T("输出 %s: %w"), target.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// renderOutputTarget 生成一个输出目标的内容，并返回该目标的文件权限。enc 不为 nil 时加密内容：
+// 原始结果在 enc.fields 时只加密字段，其余情况加密整个文件
+func renderOutputTarget(target types.OutputTarget, output *types.OutputConfig, opts template.Options, enc *outputEncryption) ([]byte, os.FileMode, error) {
+	if target.Redact {
+		output = redact.Output(output)
+	}
+	encryptFields := enc != nil && enc.fields && target.Template == ""
+	if encryptFields {
+		encrypted, err := encrypt.EncryptOutput(output, enc.recipients)
+		if err != nil {
+			return nil, 0, err
+		}
+		output = encrypted
+	}
+
+	var content []byte
+	mode := defaultOutputMode
+	switch {
+	case template.IsFormat(target.Template):
+		if template.PerHostFormat(target.Template) && len(output.Users) > 1 {
+			slog.Warn(i18n.T("同一主机有多个用户，客户端只会使用文件中的第一条凭证"), "path", target.Path, "format", target.Template, "users", len(output.Users))
+		}
+		result, err := template.RenderFormat(target.Template, output)
+		if err != nil {
+			return nil, 0, err
+		}
+		content = []byte(result)
+	case target.Template != "":
+		result, err := template.RenderTemplate(target.Template, output, opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		content = []byte(result)
+	default:
+		data, err := config.MarshalOutput(target.Path, target.Format, output)
+		if err != nil {
+			return nil, 0, err
+		}
+		content = data
+	}
+	if enc != nil && !encryptFields {
+		encrypted, err := encrypt.Encrypt(content, enc.recipients)
+		if err != nil {
+			return nil, 0, fmt.Errorf(i18n.T("加密失败: %w"), err)
+		}
+		content = encrypted
+	}
+
+	if target.Mode != "" {
+		parsed, err := utils.ParseFileMode(target.Mode)
+		if err != nil {
+			return nil, 0, err
+		}
+		mode = parsed
+	}
+	return content, mode, nil
+}
+
+// saveOutputs 用同一个 OutputConfig 生成所有输出目标。所有目标都生成成功后才开始写文件，
+// 每个文件通过临时文件加重命名原子地写入；enc 不为 nil 时所有文件都加密
+func saveOutputs(targets []types.OutputTarget, output *types.OutputConfig, opts template.Options, enc *outputEncryption) error {
+	contents := make([][]byte, len(targets))
+	modes := make([]os.FileMode, len(targets))
+	for i, target := range targets {
+		content, mode, err := renderOutputTarget(target, output, opts, enc)
+		if err != nil {
+			return fmt.Errorf(i18n.T("输出 %s: %w"), target.Path, err)
+		}
+		contents[i], modes[i] = content, mode
+	}
+
+	for i, target := range targets {
+		if err := utils.WriteFileAtomic(target.Path, contents[i], modes[i]); err != nil {
+			return fmt.Errorf(i18n.T("写入输出文件 %s 失败: %w"), target.Path, err)
+		}
+		if target.Path == utils.StdioPath {
+			continue
+		}
+		slog.Info(i18n.T("结果已保存"), "path", target.Path, "template", target.Template)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/encrypt"
+	"gitlab-cli-sdk/internal/template"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// TestSaveOutputs verifies that --output targets and config outputs are all written from the
+// same OutputConfig with their own format and file mode.
+func TestSaveOutputs(t *testing.T) {
+	dir := t.TempDir()
+	tpl := filepath.Join(dir, "names.tpl")
+	if err := os.WriteFile(tpl, []byte(`{{ range .Users }}{{ .Username }}{{ end }}`), 0600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	cfg := &config.CLIConfig{
+		OutputFiles: []string{path("output.json"), path("names.txt") + ":" + tpl + ":0640", path("secret.yaml") + ":k8s-secret"},
+	}
+	targets, err := outputTargets(cfg, []types.OutputTarget{{Path: path("raw.out"), Format: "toml", Mode: "0600"}})
+	if err != nil {
+		t.Fatalf("outputTargets() error = %v", err)
+	}
+	if err := checkOutputTargets(targets, false); err != nil {
+		t.Fatalf("checkOutputTargets() error = %v", err)
+	}
+
+	output := &types.OutputConfig{RunID: "r1", Users: []types.UserOutput{{Username: "bot", Password: "pw"}}}
+	if err := saveOutputs(targets, output, template.Options{}, nil); err != nil {
+		t.Fatalf("saveOutputs() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+		mode os.FileMode
+	}{
+		{name: "raw.out", want: `run_id = "r1"`, mode: 0600},
+		{name: "output.json", want: `"run_id": "r1"`, mode: 0600},
+		{name: "names.txt", want: "bot", mode: 0640},
+		{name: "secret.yaml", want: "kind: Secret", mode: 0600},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(path(tt.name))
+		if err != nil {
+			t.Fatalf("read %s: %v", tt.name, err)
+		}
+		if !strings.Contains(string(data), tt.want) {
+			t.Errorf("%s does not contain %q:\n%s", tt.name, tt.want, data)
+		}
+		if info, _ := os.Stat(path(tt.name)); info.Mode().Perm() != tt.mode {
+			t.Errorf("%s mode = %o, want %o", tt.name, info.Mode().Perm(), tt.mode)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temporary file %s was left behind", entry.Name())
+		}
+	}
+}
+
+func TestCheckOutputTargetsErrors(t *testing.T) {
+	tests := map[string][]types.OutputTarget{
+		"duplicate path":   {{Path: "out.yaml"}, {Path: "out.yaml", Template: "dotenv"}},
+		"invalid mode":     {{Path: "out.yaml", Mode: "0999"}},
+		"missing template": {{Path: "out.txt", Template: "does-not-exist.tpl"}},
+		"ssh-config":       {{Path: "ssh_config", Template: "ssh-config"}},
+	}
+	for name, targets := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := checkOutputTargets(targets, false); err == nil {
+				t.Errorf("checkOutputTargets() error = nil, want an error")
+			}
+		})
+	}
+}
+
+// TestSaveOutputsEncrypted verifies that --encrypt-fields keeps raw results readable while
+// templates are encrypted whole, and that both are read back with --identity.
+func TestSaveOutputsEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	identity, recipient, err := encrypt.GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity() error = %v", err)
+	}
+	keyFile := filepath.Join(dir, "key.txt")
+	if err := os.WriteFile(keyFile, []byte(identity), 0600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	tpl := filepath.Join(dir, "all.tpl")
+	if err := os.WriteFile(tpl, []byte("{{toYaml .}}"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	enc, err := newOutputEncryption(&config.CLIConfig{EncryptTo: []string{recipient}, EncryptFields: true})
+	if err != nil {
+		t.Fatalf("newOutputEncryption() error = %v", err)
+	}
+	raw, rendered := filepath.Join(dir, "output.json"), filepath.Join(dir, "rendered.yaml")
+	targets := []types.OutputTarget{{Path: raw}, {Path: rendered, Template: tpl}}
+	output := &types.OutputConfig{Users: []types.UserOutput{{Username: "bot", Password: "Passw0rd!"}}}
+	if err := saveOutputs(targets, output, template.Options{}, enc); err != nil {
+		t.Fatalf("saveOutputs() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(raw)
+	if !strings.Contains(string(data), `"username": "bot"`) || strings.Contains(string(data), "Passw0rd!") {
+		t.Errorf("output.json should keep the username and hide the password:\n%s", data)
+	}
+	if data, _ := os.ReadFile(rendered); !encrypt.IsEncrypted(data) {
+		t.Errorf("rendered.yaml is not encrypted:\n%s", data)
+	}
+
+	if err := encrypt.LoadIdentities([]string{keyFile}); err != nil {
+		t.Fatalf("LoadIdentities() error = %v", err)
+	}
+	t.Cleanup(func() { _ = encrypt.LoadIdentities(nil) })
+	for _, path := range []string{raw, rendered} {
+		loaded, err := config.LoadOutput(path, "")
+		if err != nil {
+			t.Fatalf("LoadOutput(%s) error = %v", path, err)
+		}
+		if loaded.Users[0].Password != "Passw0rd!" {
+			t.Errorf("LoadOutput(%s) password = %q", path, loaded.Users[0].Password)
+		}
+	}
+}
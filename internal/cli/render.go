@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/encrypt"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/template"
+
+	"github.com/spf13/cobra"
+)
+
+// buildRenderCommand 构建离线渲染命令
+func buildRenderCommand(cfg *config.CLIConfig) *cobra.Command {
+	var dataFile string
+	var templates, outputs []string
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: i18n.T("使用已保存的输出结果离线渲染模板"),
+		Long: i18n.T(`读取 user create -o 保存的输出结果，使用模板渲染，不连接 GitLab。
+修改模板后无需重新创建用户，也可以从一次创建生成多种格式的文件。
+
+可以重复 -t 和 -o 渲染多个模板，第 N 个模板写到第 N 个输出文件；
+只有一个模板且不指定 -o 时输出到标准输出。
+
+示例:
+  gitlab-cli render --data output.yaml -t template.yaml
+  gitlab-cli render --data output.yaml -t template.yaml -o result.yaml
+  gitlab-cli render --data output.json -t ci.tpl -o ci.env -t secret.tpl -o secret.yaml
+  gitlab-cli render --identity key.txt --data output.yaml.age -t secret.tpl -o secret.yaml`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRender(cfg, dataFile, templates, outputs)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dataFile, "data", "d", "", i18n.T("user create 保存的输出文件（- 表示标准输入）"))
+	cmd.Flags().StringArrayVarP(&templates, "template", "t", nil, i18n.T("模板文件，可重复指定"))
+	cmd.Flags().StringArrayVarP(&outputs, "output", "o", nil, i18n.T("渲染结果的输出文件，与 --template 按顺序对应（- 表示标准输出）"))
+	cmd.Flags().StringVar(&cfg.Format, "format", "", i18n.T("输出结果文件的格式: yaml、json 或 toml（默认根据扩展名判断）"))
+	cmd.Flags().BoolVar(&cfg.Redact, "redact", false, i18n.T("渲染前把密码和 Token 替换为 ${<USERNAME>_TOKEN} 形式的引用"))
+	addEncryptFlags(cmd, cfg)
+	cmd.Flags().BoolVar(&cfg.StrictTemplate, "strict-template", false, i18n.T("模板严格模式：访问不存在的键或未设置的环境变量时报错"))
+	_ = cmd.MarkFlagRequired("data")
+	_ = cmd.MarkFlagRequired("template")
+
+	return cmd
+}
+
+// runRender 执行离线渲染命令
+func runRender(cfg *config.CLIConfig, dataFile string, templates, outputs []string) error {
+	if len(outputs) == 0 && len(templates) == 1 {
+		outputs = []string{config.StdioPath}
+	}
+	if len(outputs) != len(templates) {
+		return fmt.Errorf(i18n.T("指定了 %d 个模板和 %d 个输出文件：每个 --template 都需要对应的 --output"), len(templates), len(outputs))
+	}
+
+	enc, err := newOutputEncryption(cfg)
+	if err != nil {
+		return err
+	}
+	output, err := config.LoadOutput(dataFile, cfg.Format)
+	if err != nil {
+		return err
+	}
+	redact.AddOutput(output)
+	if cfg.Redact {
+		output = redact.Output(output)
+	}
+	slog.Info(i18n.T("读取输出结果"), "path", dataFile, "users", len(output.Users))
+
+	// 先渲染所有模板，全部成功后再写文件，避免只生成部分文件
+	opts := template.Options{Strict: cfg.StrictTemplate}
+	results := make([]string, len(templates))
+	for i, templateFile := range templates {
+		result, err := template.RenderTemplate(templateFile, output, opts)
+		if err != nil {
+			return fmt.Errorf(i18n.T("渲染 %s 失败: %w"), templateFile, err)
+		}
+		results[i] = result
+	}
+
+	for i, outputFile := range outputs {
+		if enc != nil {
+			encrypted, err := encrypt.Encrypt([]byte(results[i]), enc.recipients)
+			if err != nil {
+				return fmt.Errorf(i18n.T("加密 %s 失败: %w"), outputFile, err)
+			}
+			results[i] = string(encrypted)
+		}
+		if err := template.WriteOutput(outputFile, results[i]); err != nil {
+			return err
+		}
+		if outputFile != config.StdioPath {
+			slog.Info(i18n.T("模板已渲染"), "template", templates[i], "path", outputFile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gitlab-cli-sdk/internal/config"
+)
+
+// TestRunRenderMultipleTemplates verifies that every template is rendered from the same
+// output file into its own output, and that no file is written when a template fails.
+func TestRunRenderMultipleTemplates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+	data := write("output.json", `{"run_id": "r1", "users": [{"username": "bot-r1", "logical_name": "bot"}]}`)
+	names := write("names.tpl", `{{ range .Users }}{{ .Username }}{{ end }}`)
+	runID := write("run.tpl", `{{ .RunID }}`)
+	broken := write("broken.tpl", `{{ (lookup "missing").Username }}`)
+
+	namesOut, runOut := filepath.Join(dir, "names.txt"), filepath.Join(dir, "run.txt")
+	if err := runRender(&config.CLIConfig{}, data, []string{names, runID}, []string{namesOut, runOut}); err != nil {
+		t.Fatalf("runRender() error = %v", err)
+	}
+	for path, want := range map[string]string{namesOut: "bot-r1", runOut: "r1"} {
+		if got, _ := os.ReadFile(path); string(got) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(path), got, want)
+		}
+	}
+
+	brokenOut := filepath.Join(dir, "first.txt")
+	if err := runRender(&config.CLIConfig{}, data, []string{names, broken}, []string{brokenOut, filepath.Join(dir, "second.txt")}); err == nil {
+		t.Fatalf("runRender() error = nil, want an error for the broken template")
+	}
+	if _, err := os.Stat(brokenOut); !os.IsNotExist(err) {
+		t.Errorf("first output was written although a later template failed")
+	}
+}
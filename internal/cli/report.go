@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/utils"
+)
+
+// reportWriters 按扩展名选择报告格式
+var reportWriters = map[string]func(summary *result.Summary, w io.Writer, run result.RunInfo) error{
+	".xml": (*result.Summary).WriteJUnit,
+	".md":  (*result.Summary).WriteMarkdown,
+}
+
+// addReportFlag 注册运行报告参数
+func addReportFlag(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().StringArrayVar(&cfg.Reports, "report", nil, i18n.T("运行结束后写入报告，可重复指定；按扩展名输出 JUnit XML（.xml）或 Markdown 表格（.md）"))
+}
+
+// runReport 保存 reportSummary 生成的结果汇总，运行结束后据此写出报告
+type runReport struct {
+	summary *result.Summary
+}
+
+type reportKey struct{}
+
+// keepSummary 把结果汇总交给 context 中的 runReport，没有指定 --report 时什么也不做
+func keepSummary(ctx context.Context, summary *result.Summary) {
+	if report, ok := ctx.Value(reportKey{}).(*runReport); ok {
+		report.summary = summary
+	}
+}
+
+// reportRun 执行 run，结束后按 --report 写出运行报告。
+// 报告和最终汇总来自同一个结果模型；运行在处理资源前就失败时（例如认证失败），报告中只有这个错误
+func reportRun(ctx context.Context, cfg *config.CLIConfig, name string, run func(ctx context.Context) error) error {
+	if len(cfg.Reports) == 0 {
+		return run(ctx)
+	}
+	for _, path := range cfg.Reports {
+		if _, ok := reportWriters[strings.ToLower(filepath.Ext(path))]; !ok {
+			return fmt.Errorf(i18n.T("不支持的报告格式 %q，报告文件的扩展名必须是 .xml 或 .md"), path)
+		}
+	}
+
+	report := &runReport{summary: &result.Summary{}}
+	started := time.Now()
+	err := run(context.WithValue(ctx, reportKey{}, report))
+
+	info := result.RunInfo{Name: name, Duration: time.Since(started)}
+	// 资源操作失败已经体现在结果汇总中，只有中断运行的错误单独列出
+	var exitErr *ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		info.Err = err
+	}
+
+	for _, path := range cfg.Reports {
+		// 运行本身的错误优先，报告写入失败时只记录日志
+		if writeErr := writeReport(path, report.summary, info); writeErr != nil {
+			if err == nil {
+				err = writeErr
+				continue
+			}
+			slog.Error(i18n.T("写入报告失败"), "path", path, logging.Err(writeErr))
+		} else {
+			slog.Info(i18n.T("报告已写入"), "path", path)
+		}
+	}
+	return err
+}
+
+// writeReport 按扩展名生成报告并写入文件，失败原因中的凭证会被隐藏
+func writeReport(path string, summary *result.Summary, run result.RunInfo) error {
+	var buf bytes.Buffer
+	if err := reportWriters[strings.ToLower(filepath.Ext(path))](summary, &buf, run); err != nil {
+		return fmt.Errorf("write report %s: %w", path, err)
+	}
+	if err := utils.WriteFileAtomic(path, []byte(redact.String(buf.String())), 0644); err != nil {
+		return fmt.Errorf("write report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/tracing"
+	"gitlab-cli-sdk/internal/utils"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// traceSummaryLimit 是 --trace-summary 列出的操作数
+const traceSummaryLimit = 10
+
+// addTraceFlags 注册 trace 相关参数
+func addTraceFlags(cmd *cobra.Command, cfg *config.CLIConfig) {
+	cmd.Flags().StringVar(&cfg.TraceFile, "trace-file", "", i18n.T("运行结束后以 OTLP/JSON 格式写入 trace 的文件（- 表示标准输出）"))
+	cmd.Flags().BoolVar(&cfg.TraceSummary, "trace-summary", false, i18n.T("运行结束后列出耗时最长的操作"))
+}
+
+// traceRun 在表示整个运行的根 span 中执行 run，结束后按 --trace-file 导出 trace，
+// 并按 --trace-summary 列出耗时最长的操作；两者都未指定时直接执行 run
+func traceRun(ctx context.Context, cfg *config.CLIConfig, action result.Action, run func(ctx context.Context) error) error {
+	if cfg.TraceFile == "" && !cfg.TraceSummary {
+		return run(ctx)
+	}
+	if cfg.TraceFile == utils.StdioPath && cfg.EventsFile == utils.StdioPath {
+		return errors.New(i18n.T("--trace-file - 和 --events-file - 不能同时输出到标准输出"))
+	}
+
+	tracer := tracing.New()
+	ctx, span := tracing.Start(tracing.NewContext(ctx, tracer), "run", slog.String(logging.KeyAction, string(action)))
+	err := run(ctx)
+	span.End(err)
+
+	if cfg.TraceSummary {
+		printTraceSummary(tracer.Slowest(traceSummaryLimit))
+	}
+	if cfg.TraceFile != "" {
+		// 运行本身的错误优先，trace 写入失败时只记录日志
+		if writeErr := writeTrace(cfg.TraceFile, tracer); writeErr != nil {
+			if err == nil {
+				return writeErr
+			}
+			slog.Error(i18n.T("写入 trace 失败"), logging.Err(writeErr))
+		} else {
+			slog.Info(i18n.T("trace 已写入"), "path", cfg.TraceFile)
+		}
+	}
+	return err
+}
+
+// writeTrace 把 trace 写入文件（权限 0600），path 为 - 时写到标准输出
+func writeTrace(path string, tracer *tracing.Tracer) error {
+	var buf bytes.Buffer
+	if err := tracer.WriteOTLP(&buf); err != nil {
+		return fmt.Errorf("write trace: %w", err)
+	}
+	if err := utils.WriteFileAtomic(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("write trace: %w", err)
+	}
+	return nil
+}
+
+// checkTraceTarget 检查 trace 和输出结果没有同时写到标准输出
+func checkTraceTarget(cfg *config.CLIConfig, targets []types.OutputTarget) error {
+	if cfg.TraceFile != utils.StdioPath {
+		return nil
+	}
+	for _, target := range targets {
+		if target.Path == utils.StdioPath {
+			return errors.New(i18n.T("--trace-file - 和 --output - 不能同时输出到标准输出"))
+		}
+	}
+	return nil
+}
+
+// printTraceSummary 列出耗时最长的操作：文本日志时输出表格，JSON 日志时每个操作输出一条日志
+func printTraceSummary(spans []*tracing.Span) {
+	if logging.IsJSON() {
+		for _, span := range spans {
+			slog.Info(i18n.T("耗时最长的操作"), "span", span.Name(), "attributes", spanAttributes(span), logging.KeyDuration, span.Duration())
+		}
+		return
+	}
+	writeTraceSummary(logging.Console(), spans)
+}
+
+// writeTraceSummary 以表格形式输出 span 的耗时、名称和属性
+func writeTraceSummary(w io.Writer, spans []*tracing.Span) {
+	fmt.Fprintln(w, i18n.T("耗时最长的操作:"))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DURATION\tSPAN\tATTRIBUTES\tERROR")
+	for _, span := range spans {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", span.Duration().Round(time.Millisecond), span.Name(), spanAttributes(span), firstLine(span.Err()))
+	}
+	tw.Flush()
+}
+
+// spanAttributes 把 span 属性格式化为 key=value 列表
+func spanAttributes(span *tracing.Span) string {
+	attrs := make([]string, 0, len(span.Attributes()))
+	for _, attr := range span.Attributes() {
+		attrs = append(attrs, attr.String())
+	}
+	return strings.Join(attrs, " ")
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gitlab-cli-sdk/internal/config"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/processor"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// buildVerifyCommand 构建输出结果校验命令
+func buildVerifyCommand(cfg *config.CLIConfig) *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: i18n.T("检查输出结果中记录的资源是否仍与 GitLab 一致"),
+		Long: i18n.T(`读取 user create -o 保存的输出结果，逐个检查其中记录的资源是否仍与 GitLab 一致：
+  - 用户存在且处于 active 状态
+  - 组和项目存在，ID 和可见性与记录相同
+  - Token 能够认证（通过 /personal_access_tokens/self），属于该用户并带有记录的权限范围
+不修改任何资源。与记录不一致（漂移）的资源在汇总中记为失败并列出差异，退出码与其他命令相同。
+使用 --redact 保存的输出结果中 Token 是 ${<USERNAME>_TOKEN} 形式的引用，从环境变量读取。
+
+示例:
+  gitlab-cli verify --from-output output.yaml
+  gitlab-cli verify --from-output output.json --report verify.xml
+  gitlab-cli verify --identity key.txt --from-output output.yaml.age`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return instrumentRun(cmd, cfg, result.ActionVerify, func(ctx context.Context) error {
+				return runVerify(ctx, cfg, outputFile)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFile, "from-output", "", i18n.T("user create 保存的输出文件（- 表示标准输入）"))
+	cmd.Flags().StringVar(&cfg.Format, "format", "", i18n.T("输出结果文件的格式: yaml、json 或 toml（默认根据扩展名判断）"))
+	cmd.Flags().StringVar(&cfg.GitLabHost, "host", "", i18n.T("GitLab 主机地址"))
+	cmd.Flags().StringVar(&cfg.GitLabToken, "token", "", i18n.T("GitLab 个人访问令牌（Personal Access Token）"))
+	addRetryFlags(cmd, cfg)
+	addTraceFlags(cmd, cfg)
+	addMetricsFlag(cmd, cfg)
+	addReportFlag(cmd, cfg)
+	_ = cmd.MarkFlagRequired("from-output")
+
+	return cmd
+}
+
+// runVerify 执行输出结果校验命令：逐个用户检查记录的资源，结束后输出汇总，有漂移时返回对应退出码的错误
+func runVerify(ctx context.Context, cfg *config.CLIConfig, outputFile string) error {
+	output, err := config.LoadOutput(outputFile, cfg.Format)
+	if err != nil {
+		return err
+	}
+	if len(output.Users) == 0 {
+		return errors.New(i18n.T("输出结果中没有用户"))
+	}
+	redact.AddOutput(output)
+	slog.Info(i18n.T("读取输出结果"), "path", outputFile, "users", len(output.Users))
+
+	gitlabClient, err := initializeClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer gitlabClient.CloseIdleConnections()
+	defer logRetrySummary(gitlabClient)
+	runStarted := time.Now()
+
+	proc := &processor.ResourceProcessor{Client: gitlabClient}
+
+	var recorders []*result.Recorder
+	for i, userOutput := range output.Users {
+		recorder := result.NewRecorder(userOutput.Username)
+		recorders = append(recorders, recorder)
+
+		logger := userLogger(userOutput.Username, result.ActionVerify)
+		logger.Info(i18n.T("处理用户"), "index", i+1, "total", len(output.Users))
+
+		if err := proc.ForUser(logger, recorder).ProcessUserVerify(ctx, userOutput); err != nil {
+			reportCancelled(usernames(output.Users[i:]))
+			_ = reportSummary(ctx, result.NewSummary(recorders...))
+			return err
+		}
+	}
+
+	slog.Info(i18n.T("校验完成"), logging.Duration(runStarted))
+	return reportSummary(ctx, result.NewSummary(recorders...))
+}
+
+// usernames 返回输出结果中用户的用户名
+func usernames(users []types.UserOutput) []string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = user.Username
+	}
+	return names
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"time"
 
+	"gitlab-cli-sdk/internal/utils"
 	"gitlab-cli-sdk/pkg/types"
 	"gopkg.in/yaml.v3"
 )
@@ -11,13 +14,40 @@ import (
 // CLIConfig 封装CLI配置参数
 type CLIConfig struct {
 	ConfigFile        string
+	Format            string // 配置和输出文件的格式: yaml、json 或 toml，为空时根据扩展名判断
 	GitLabHost        string
 	GitLabToken       string
-	OutputFile        string // 输出文件路径
-	TemplateFile      string // 模板文件路径
-	DaysOld           int    // 只删除创建日期超过指定天数的用户（cleanup 命令使用）
-	GitLabSSHEndpoint string // GitLab SSH endpoint (e.g., ssh://git@host:22)
-	NameSuffix        string // Optional custom suffix used in prefix naming mode.
+	OutputFiles       []string      // 输出目标 path[:template[:mode]]，与配置文件中的 outputs 一起生效
+	TemplateFile      string        // 模板文件路径，用于未指定模板的输出目标
+	Redact            bool          // 输出文件中的密码和 Token 替换为引用，内置格式除外
+	ShowSecrets       bool          // 日志中显示密码和 Token，默认隐藏
+	LogLevel          string        // 日志级别: debug、info、warn 或 error
+	LogFormat         string        // 日志格式: text 或 json
+	LogFile           string        // 日志文件，为空时输出到标准错误
+	Lang              string        // 消息语言: zh 或 en，为空时根据 LANG 判断
+	EncryptTo         []string      // 输出文件的加密接收者：age1 开头的公钥或 passphrase
+	EncryptFields     bool          // 只加密输出结果中的密码和 Token 字段，而不是整个文件
+	Identities        []string      // 解密配置和输出结果使用的 age 私钥文件
+	StrictTemplate    bool          // 模板严格模式：访问不存在的键或未设置的环境变量时报错
+	OutputFormat      string        // 内置输出格式，例如 dotenv、k8s-secret，用于未指定模板的输出目标，与 TemplateFile 互斥
+	DaysOld           int           // 只删除创建日期超过指定天数的用户（cleanup 命令使用）
+	GitLabSSHEndpoint string        // GitLab SSH endpoint (e.g., ssh://git@host:22)
+	NameSuffix        string        // Optional custom suffix used in prefix naming mode.
+	RunID             string        // 本次运行的标识，prefix 模式生成的名称都以它结尾；为空时自动生成
+	CheckpointFile    string        // 断点文件路径，每完成一个资源就更新一次
+	ResumeFile        string        // 从该断点文件恢复中断的创建流程
+	EventsFile        string        // 资源操作事件的 NDJSON 输出文件，- 表示标准输出
+	AuditLog          string        // 修改性 API 调用的审计日志，为空时不记录
+	TraceFile         string        // OTLP/JSON 格式的 trace 输出文件，- 表示标准输出
+	TraceSummary      bool          // 运行结束后列出耗时最长的操作
+	MetricsFile       string        // Prometheus textfile 格式的指标输出文件
+	Reports           []string      // 运行报告文件，按扩展名输出 JUnit XML（.xml）或 Markdown（.md）
+	Concurrency       int           // 并发处理的用户数
+	RateLimit         float64       // 所有并发任务共享的每秒最大请求数，0 表示不限制
+	WaitTimeout       time.Duration // 每次等待 GitLab 完成异步删除的最长时间
+	RetryMaxAttempts  int           // 瞬时 API 错误的最大尝试次数（包含第一次），1 表示不重试
+	RetryBaseDelay    time.Duration // 第一次重试前的退避时间，之后每次翻倍
+	RetryMaxDelay     time.Duration // 指数退避的上限
 }
 
 // LoadGitLabCredentials 从环境变量或命令行参数加载 GitLab 凭证
@@ -41,29 +71,99 @@ func LoadGitLabCredentials(cfg *CLIConfig) error {
 	return nil
 }
 
-// Load 加载配置文件
-func Load(configFile string) (*types.UserConfig, error) {
-	data, err := os.ReadFile(configFile)
+// Load 加载配置文件及其 include 的文件。返回前合并所有文档的 defaults 和 profiles，
+// 再展开 count 和 matrix，因此返回的每个用户都是完整的定义。
+// format 为空时根据扩展名选择 YAML、JSON 或 TOML，configFile 为 "-" 时从标准输入读取
+func Load(configFile, format string) (*types.UserConfig, error) {
+	format, err := DetectFormat(configFile, format)
 	if err != nil {
-		return nil, fmt.Errorf("read config file: %w", err)
+		return nil, err
 	}
+	sources, err := loadSources(configFile, format, nil, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+	return mergeSources(sources)
+}
+
+// WriteConfig 按 format 格式输出配置，供 config expand 查看展开后的结果
+func WriteConfig(w io.Writer, cfg *types.UserConfig, format string) error {
+	if format != FormatYAML {
+		data, err := encode(cfg, format)
+		if err != nil {
+			return fmt.Errorf("marshal config: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	return encoder.Close()
+}
 
-	var cfg types.UserConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("parse config file: %w", err)
+// mappingValue 返回 mapping 节点中 key 对应的值，不存在时返回 nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(node, key); i >= 0 {
+		return node.Content[i+1]
 	}
+	return nil
+}
 
-	return &cfg, nil
+// LoadOutput 读取 SaveOutput 保存的输出结果。format 为空时根据扩展名选择 YAML、JSON 或 TOML，
+// outputFile 为 "-" 时从标准输入读取
+func LoadOutput(outputFile, format string) (*types.OutputConfig, error) {
+	format, err := DetectFormat(outputFile, format)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readFile(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("read output file: %w", err)
+	}
+	docs, err := decodeDocuments(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("parse output file %s: %w", outputFile, err)
+	}
+
+	var output types.OutputConfig
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		if err := doc.Decode(&output); err != nil {
+			return nil, fmt.Errorf("parse output file %s: %w", outputFile, err)
+		}
+		break
+	}
+	return &output, nil
+}
+
+// MarshalOutput 按 format 编码输出结果。format 为空时根据 outputFile 的扩展名选择 YAML、JSON 或 TOML
+func MarshalOutput(outputFile, format string, output *types.OutputConfig) ([]byte, error) {
+	format, err := DetectFormat(outputFile, format)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encode(output, format)
+	if err != nil {
+		return nil, fmt.Errorf("marshal output: %w", err)
+	}
+	return data, nil
 }
 
-// SaveOutput 保存输出结果到 YAML 文件
-func SaveOutput(outputFile string, output *types.OutputConfig) error {
-	data, err := yaml.Marshal(output)
+// SaveOutput 保存输出结果到文件。format 为空时根据扩展名选择 YAML、JSON 或 TOML，
+// outputFile 为 "-" 时写到标准输出。输出结果包含密码和 Token，因此只允许当前用户读写
+func SaveOutput(outputFile, format string, output *types.OutputConfig) error {
+	data, err := MarshalOutput(outputFile, format, output)
 	if err != nil {
-		return fmt.Errorf("marshal output: %w", err)
+		return err
 	}
 
-	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+	if err := utils.WriteFileAtomic(outputFile, data, 0600); err != nil {
 		return fmt.Errorf("write output file: %w", err)
 	}
 
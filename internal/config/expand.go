@@ -0,0 +1,300 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// variablePattern matches ${name} and ${level.name} placeholders in config values, and the
+// escaped form $${name} that stands for a literal ${name}.
+var variablePattern = regexp.MustCompile(`(\$?)\$\{([a-zA-Z_][a-zA-Z0-9_]*(?:\.[a-zA-Z_][a-zA-Z0-9_]*)?)\}`)
+
+// maxCopies limits how many copies count and matrix may produce from one item, so that a typo
+// such as count: 100000 fails early instead of creating thousands of GitLab resources.
+const maxCopies = 1000
+
+// expandLevel describes one level of the config that supports count and matrix.
+type expandLevel struct {
+	name     string   // name is the variable scope, e.g. ${user.index}
+	children []string // children are the keys whose sequences are expanded one level deeper
+}
+
+var (
+	userLevel    = expandLevel{name: "user", children: []string{"groups", "projects"}}
+	groupLevel   = expandLevel{name: "group", children: []string{"projects"}}
+	projectLevel = expandLevel{name: "project"}
+)
+
+// childLevel returns the level of the sequence stored under key.
+func childLevel(key string) expandLevel {
+	if key == "groups" {
+		return groupLevel
+	}
+	return projectLevel
+}
+
+// expandUsers 展开 users 列表中的 count 和 matrix，并替换展开项中的 ${...} 变量。
+//
+// count: N 把一项复制 N 次；matrix 把一项按每个变量的取值组合复制，两者同时出现时取乘积。
+// 声明了 count 或 matrix 的项及其内层的组和项目可使用以下变量：
+//   - ${index}：在本次展开中的序号（从 1 开始），内层未展开的项为 1
+//   - ${<变量>}：matrix 中该变量的取值
+//   - ${user.index}、${group.index}、${project.<变量>} 等：指定层级的变量，供内层引用外层
+//
+// 每项最多展开为 maxCopies 份，超出时报错。
+// 内层的同名变量会覆盖外层的变量；引用未定义的变量会报错，$${...} 表示字面的 ${...}。
+// 不在任何展开项中的值原样保留，其中的 ${...}（例如密码或模板字符串）不受影响。
+func expandUsers(users *yaml.Node) error {
+	expanded, err := expandSequence(users, userLevel, nil, "users")
+	if err != nil {
+		return err
+	}
+	users.Content = expanded
+	return nil
+}
+
+// expandSequence expands every item of seq and returns the resulting items.
+func expandSequence(seq *yaml.Node, level expandLevel, parentVars map[string]string, path string) ([]*yaml.Node, error) {
+	if seq.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s: expected a list", path)
+	}
+
+	var items []*yaml.Node
+	for i, item := range seq.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		copies, err := expandItem(item, level, parentVars, itemPath)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, copies...)
+	}
+	return items, nil
+}
+
+// expandItem replicates one item according to its count and matrix. parentVars is nil outside
+// of expanded items; an item that declares neither count nor matrix there is kept as is.
+func expandItem(item *yaml.Node, level expandLevel, parentVars map[string]string, path string) ([]*yaml.Node, error) {
+	if item.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected a mapping", path)
+	}
+
+	count := 1
+	var matrix []matrixAxis
+	declared := false
+	base := &yaml.Node{Kind: yaml.MappingNode, Tag: item.Tag, Line: item.Line, Column: item.Column}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key, value := item.Content[i], item.Content[i+1]
+		switch key.Value {
+		case "count":
+			declared = true
+			n, err := strconv.Atoi(value.Value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%s.count: must be a positive integer, got %q", path, value.Value)
+			}
+			if n > maxCopies {
+				return nil, fmt.Errorf("%s.count: %d exceeds the limit of %d copies", path, n, maxCopies)
+			}
+			count = n
+		case "matrix":
+			declared = true
+			axes, err := parseMatrix(value, path+".matrix")
+			if err != nil {
+				return nil, err
+			}
+			matrix = axes
+		default:
+			base.Content = append(base.Content, key, value)
+		}
+	}
+
+	if !declared && parentVars == nil {
+		copied, err := expandCopy(base, level, nil, path)
+		if err != nil {
+			return nil, err
+		}
+		return []*yaml.Node{copied}, nil
+	}
+
+	total := count
+	for _, axis := range matrix {
+		total *= len(axis.values)
+		if total > maxCopies {
+			return nil, fmt.Errorf("%s: count and matrix produce more than the limit of %d copies", path, maxCopies)
+		}
+	}
+
+	combinations := matrixCombinations(matrix)
+	var copies []*yaml.Node
+	for _, combination := range combinations {
+		for c := 0; c < count; c++ {
+			vars := make(map[string]string, len(parentVars)+2*len(combination)+2)
+			for k, v := range parentVars {
+				vars[k] = v
+			}
+			index := strconv.Itoa(len(copies) + 1)
+			vars["index"] = index
+			vars[level.name+".index"] = index
+			for _, v := range combination {
+				vars[v.name] = v.value
+				vars[level.name+"."+v.name] = v.value
+			}
+
+			copied, err := expandCopy(base, level, vars, path)
+			if err != nil {
+				return nil, err
+			}
+			copies = append(copies, copied)
+		}
+	}
+	return copies, nil
+}
+
+// expandCopy deep-copies an item, substituting variables in its own values and expanding the
+// nested lists of the next level with the item's variables in scope. With nil vars the values are
+// copied without substitution.
+func expandCopy(item *yaml.Node, level expandLevel, vars map[string]string, path string) (*yaml.Node, error) {
+	copied := &yaml.Node{Kind: item.Kind, Tag: item.Tag, Line: item.Line, Column: item.Column}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key, value := item.Content[i], item.Content[i+1]
+		valuePath := path + "." + key.Value
+
+		if containsString(level.children, key.Value) && value.Kind == yaml.SequenceNode {
+			children, err := expandSequence(value, childLevel(key.Value), vars, valuePath)
+			if err != nil {
+				return nil, err
+			}
+			seq := *value
+			seq.Content = children
+			copied.Content = append(copied.Content, key, &seq)
+			continue
+		}
+
+		if vars == nil {
+			copied.Content = append(copied.Content, key, value)
+			continue
+		}
+		substituted, err := substituteNode(value, vars, valuePath)
+		if err != nil {
+			return nil, err
+		}
+		copied.Content = append(copied.Content, key, substituted)
+	}
+	return copied, nil
+}
+
+// substituteNode deep-copies node, replacing ${...} variables in every scalar.
+func substituteNode(node *yaml.Node, vars map[string]string, path string) (*yaml.Node, error) {
+	copied := *node
+	if node.Kind == yaml.ScalarNode {
+		value, err := substitute(node.Value, vars)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		copied.Value = value
+		return &copied, nil
+	}
+
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		substituted, err := substituteNode(child, vars, path)
+		if err != nil {
+			return nil, err
+		}
+		copied.Content[i] = substituted
+	}
+	return &copied, nil
+}
+
+// substitute replaces ${...} variables in s, turns $${...} into a literal ${...} and reports the
+// first undefined variable.
+func substitute(s string, vars map[string]string) (string, error) {
+	var missing string
+	result := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := variablePattern.FindStringSubmatch(match)
+		if groups[1] != "" {
+			return match[1:]
+		}
+		name := groups[2]
+		value, ok := vars[name]
+		if !ok {
+			if missing == "" {
+				missing = match
+			}
+			return match
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable %s", missing)
+	}
+	return result, nil
+}
+
+// matrixAxis is one variable of a matrix with its values in config order.
+type matrixAxis struct {
+	name   string
+	values []string
+}
+
+// matrixValue is the value of one matrix variable in one combination.
+type matrixValue struct {
+	name  string
+	value string
+}
+
+// parseMatrix reads a matrix mapping of variable name to list of values.
+func parseMatrix(node *yaml.Node, path string) ([]matrixAxis, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected a mapping of variable to list of values", path)
+	}
+
+	var axes []matrixAxis
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "index" {
+			return nil, fmt.Errorf("%s: %q is reserved", path, key.Value)
+		}
+		if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+			return nil, fmt.Errorf("%s.%s: expected a non-empty list", path, key.Value)
+		}
+		axis := matrixAxis{name: key.Value}
+		for _, v := range value.Content {
+			if v.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("%s.%s: values must be scalars", path, key.Value)
+			}
+			axis.values = append(axis.values, v.Value)
+		}
+		axes = append(axes, axis)
+	}
+	return axes, nil
+}
+
+// matrixCombinations returns the cartesian product of the axes; the first axis varies slowest.
+// Without axes it returns a single empty combination.
+func matrixCombinations(axes []matrixAxis) [][]matrixValue {
+	combinations := [][]matrixValue{nil}
+	for _, axis := range axes {
+		var next [][]matrixValue
+		for _, combination := range combinations {
+			for _, value := range axis.values {
+				extended := append(append([]matrixValue(nil), combination...), matrixValue{name: axis.name, value: value})
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
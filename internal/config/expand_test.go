@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes content to a temporary config file and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadExpandsCountAndMatrix(t *testing.T) {
+	path := writeConfig(t, `
+users:
+  - username: load-${index}
+    email: load-${index}@test.example.com
+    name: Load ${index} ${role}
+    count: 2
+    matrix:
+      role: [dev, ops]
+    groups:
+      - name: team-${user.index}-${index}
+        visibility: ${visibility}
+        matrix:
+          visibility: [private, internal]
+        projects:
+          - name: repo
+            description: group ${group.index} of ${user.index}
+  - username: plain
+`)
+
+	cfg, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var usernames []string
+	for _, user := range cfg.Users {
+		usernames = append(usernames, user.Username)
+	}
+	// The first matrix variable varies slowest, count varies fastest, and index runs across both.
+	if got, want := strings.Join(usernames, ","), "load-1,load-2,load-3,load-4,plain"; got != want {
+		t.Fatalf("usernames = %s, want %s", got, want)
+	}
+	if got, want := cfg.Users[2].Name, "Load 3 ops"; got != want {
+		t.Errorf("Users[2].Name = %q, want %q", got, want)
+	}
+
+	groups := cfg.Users[1].Groups
+	if len(groups) != 2 {
+		t.Fatalf("len(Users[1].Groups) = %d, want 2", len(groups))
+	}
+	if groups[1].Name != "team-2-2" || groups[1].Visibility != "internal" {
+		t.Errorf("Users[1].Groups[1] = %s/%s, want team-2-2/internal", groups[1].Name, groups[1].Visibility)
+	}
+	if got, want := groups[1].Projects[0].Description, "group 2 of 2"; got != want {
+		t.Errorf("project description = %q, want %q", got, want)
+	}
+	if cfg.Users[0].Count != 0 || cfg.Users[0].Matrix != nil {
+		t.Errorf("expanded user still has count/matrix: %+v", cfg.Users[0].Expansion)
+	}
+}
+
+func TestLoadExpandErrors(t *testing.T) {
+	tests := map[string]string{
+		"undefined variable": "users:\n  - username: u-${rol}\n    count: 2\n",
+		"invalid count":      "users:\n  - username: u-${index}\n    count: 0\n",
+		"reserved variable":  "users:\n  - username: u\n    matrix:\n      index: [a]\n",
+		"count too large":    "users:\n  - username: u-${index}\n    count: 100000\n",
+		"too many copies":    "users:\n  - username: u-${index}-${role}\n    count: 600\n    matrix:\n      role: [a, b]\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(writeConfig(t, content), ""); err == nil {
+				t.Errorf("Load() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestLoadKeepsLiteralVariables(t *testing.T) {
+	path := writeConfig(t, `
+users:
+  - username: plain
+    password: p-${FOO}
+    groups:
+      - name: team-${index}
+        count: 2
+        projects:
+          - name: repo
+            description: $${FOO} in ${group.index}
+  - username: load-${index}
+    password: $${FOO}-${index}
+    count: 2
+`)
+
+	cfg, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// Values outside of count/matrix entries are not interpolated.
+	if got, want := cfg.Users[0].Password, "p-${FOO}"; got != want {
+		t.Errorf("unexpanded password = %q, want %q", got, want)
+	}
+	if got, want := cfg.Users[0].Groups[1].Name, "team-2"; got != want {
+		t.Errorf("expanded group = %q, want %q", got, want)
+	}
+	if got, want := cfg.Users[0].Groups[1].Projects[0].Description, "${FOO} in 2"; got != want {
+		t.Errorf("description = %q, want %q", got, want)
+	}
+	if got, want := cfg.Users[2].Password, "${FOO}-2"; got != want {
+		t.Errorf("escaped password = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gitlab-cli-sdk/internal/encrypt"
+	"gitlab-cli-sdk/internal/utils"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// 支持的配置和输出文件格式
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+	FormatTOML = "toml"
+)
+
+// StdioPath 表示从标准输入读取配置或把结果写到标准输出
+const StdioPath = utils.StdioPath
+
+// DetectFormat 确定文件使用的格式：指定了 format 时使用 format，否则根据扩展名判断
+// （age 加密文件去掉 .age 后缀再判断，例如 output.json.age 为 JSON）；
+// 标准输入输出和无法识别的扩展名按 YAML 处理
+func DetectFormat(path, format string) (string, error) {
+	if format != "" {
+		switch strings.ToLower(format) {
+		case FormatYAML, "yml":
+			return FormatYAML, nil
+		case FormatJSON:
+			return FormatJSON, nil
+		case FormatTOML:
+			return FormatTOML, nil
+		}
+		return "", fmt.Errorf("unsupported format %q (want %s, %s or %s)", format, FormatYAML, FormatJSON, FormatTOML)
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(path), ".age")
+	switch filepath.Ext(name) {
+	case ".json":
+		return FormatJSON, nil
+	case ".toml":
+		return FormatTOML, nil
+	}
+	return FormatYAML, nil
+}
+
+// readFile 读取 path，path 为 "-" 时读取标准输入；age 加密的文件用 --identity 注册的身份解密
+func readFile(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == StdioPath {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return encrypt.Decrypt(data)
+}
+
+// decodeDocuments 按指定格式把 data 解析为每个文档的顶层节点，并解密 --encrypt-fields 加密的字段
+func decodeDocuments(data []byte, format string) ([]*yaml.Node, error) {
+	docs, err := parseFormat(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := encrypt.DecryptNodes(docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// parseFormat 解析 data，不解密。只有 YAML 支持一个文件包含多个文档；JSON 和 TOML 转换为
+// 单个 YAML 节点，使 include、profile 和展开在各种格式下行为一致
+func parseFormat(data []byte, format string) ([]*yaml.Node, error) {
+	switch format {
+	case FormatJSON:
+		// yaml.v3 能解析 JSON 并保留行号，但也接受 YAML，因此先校验是否为合法 JSON
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return parseDocuments(data)
+
+	case FormatTOML:
+		var v map[string]any
+		if _, err := toml.Decode(string(data), &v); err != nil {
+			return nil, err
+		}
+		var node yaml.Node
+		if err := node.Encode(normalizeTOML(v)); err != nil {
+			return nil, err
+		}
+		return []*yaml.Node{&node}, nil
+	}
+	return parseDocuments(data)
+}
+
+// normalizeTOML 把 TOML 的日期和时间转换为 YAML 配置中使用的字符串，
+// 例如 expires_at = 2030-01-01 转换为 "2030-01-01"
+func normalizeTOML(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeTOML(value)
+		}
+	case []map[string]any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = normalizeTOML(item)
+		}
+		return items
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeTOML(item)
+		}
+	case time.Time:
+		switch v.Location().String() {
+		case "date-local":
+			return v.Format(time.DateOnly)
+		case "datetime-local":
+			return v.Format("2006-01-02T15:04:05")
+		case "time-local":
+			return v.Format(time.TimeOnly)
+		}
+		return v.Format(time.RFC3339)
+	}
+	return v
+}
+
+// encode 按指定格式序列化 v，YAML 保持 yaml.Marshal 的缩进
+func encode(v any, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+
+	case FormatTOML:
+		var buf bytes.Buffer
+		encoder := toml.NewEncoder(&buf)
+		encoder.Indent = "  "
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return yaml.Marshal(v)
+}
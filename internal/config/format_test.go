@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+func TestLoadFormats(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"users.toml": `
+include = ["team.json"]
+
+[defaults.token]
+scope = ["api"]
+expires_at = 2030-01-01
+
+[[users]]
+username = "toml-${index}"
+count = 2
+`,
+		"team.json": `{"users": [{"username": "json-user", "groups": [{"name": "dev"}]}]}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "users.toml"), "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var usernames []string
+	for _, user := range cfg.Users {
+		usernames = append(usernames, user.Username)
+	}
+	if got, want := strings.Join(usernames, ","), "json-user,toml-1,toml-2"; got != want {
+		t.Fatalf("usernames = %s, want %s", got, want)
+	}
+	if got := cfg.Users[1].Token; got == nil || got.ExpiresAt != "2030-01-01" {
+		t.Errorf("TOML date = %+v, want expires_at 2030-01-01", got)
+	}
+	if groups := cfg.Users[0].Groups; len(groups) != 1 || groups[0].Name != "dev" {
+		t.Errorf("JSON groups = %+v, want [dev]", groups)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		path, format, want string
+	}{
+		{path: "users.toml", want: FormatTOML},
+		{path: "output.JSON", want: FormatJSON},
+		{path: "output.json.age", want: FormatJSON},
+		{path: "output.toml.AGE", want: FormatTOML},
+		{path: "output.age", want: FormatYAML},
+		{path: StdioPath, want: FormatYAML},
+		{path: "output.json.age", format: "yml", want: FormatYAML},
+	}
+	for _, tt := range tests {
+		got, err := DetectFormat(tt.path, tt.format)
+		if err != nil || got != tt.want {
+			t.Errorf("DetectFormat(%q, %q) = %q, %v, want %q", tt.path, tt.format, got, err, tt.want)
+		}
+	}
+}
+
+func TestSaveOutputFormats(t *testing.T) {
+	output := &types.OutputConfig{
+		Endpoint: "https://gitlab.example.com",
+		Users: []types.UserOutput{{
+			Username: "bot",
+			Token:    &types.TokenOutput{Value: "glpat-x", Scope: []string{"api"}},
+		}},
+	}
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "output.json")
+	if err := SaveOutput(jsonPath, "", output); err != nil {
+		t.Fatalf("SaveOutput(json) error = %v", err)
+	}
+	data, _ := os.ReadFile(jsonPath)
+	var decoded types.OutputConfig
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("output.json is not JSON: %v\n%s", err, data)
+	}
+	if decoded.Users[0].Token.Value != "glpat-x" {
+		t.Errorf("decoded token = %q, want glpat-x", decoded.Users[0].Token.Value)
+	}
+
+	// --format overrides the extension
+	tomlPath := filepath.Join(dir, "output.txt")
+	if err := SaveOutput(tomlPath, "toml", output); err != nil {
+		t.Fatalf("SaveOutput(toml) error = %v", err)
+	}
+	data, _ = os.ReadFile(tomlPath)
+	if !strings.Contains(string(data), `endpoint = "https://gitlab.example.com"`) {
+		t.Errorf("TOML output missing endpoint:\n%s", data)
+	}
+
+	if err := SaveOutput(filepath.Join(dir, "x"), "xml", output); err == nil {
+		t.Errorf("SaveOutput(xml) error = nil, want an error")
+	}
+}
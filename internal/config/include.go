@@ -0,0 +1,244 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitlab-cli-sdk/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// configSource 是配置中的一个 YAML 文档，可能来自 include 的文件
+type configSource struct {
+	name string     // name 用于错误信息：文件路径，多文档文件中附带文档序号
+	root *yaml.Node // root 是文档的顶层 mapping
+}
+
+// loadSources 读取 path 中的所有文档，并按顺序展开其中的 include。
+// 被 include 的文档排在包含它的文档之前，因此包含者的 defaults 覆盖被包含者的 defaults。
+// 同一个文件被多次 include 时只读取一次；循环 include 会报错。
+func loadSources(path, format string, stack []string, loaded map[string]bool) ([]configSource, error) {
+	abs := path
+	if path != StdioPath {
+		var err error
+		if abs, err = filepath.Abs(path); err != nil {
+			return nil, fmt.Errorf("resolve config file %s: %w", path, err)
+		}
+	}
+	for _, p := range stack {
+		if p == abs {
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(append(stack, abs), " -> "))
+		}
+	}
+	if loaded[abs] {
+		return nil, nil
+	}
+	loaded[abs] = true
+	stack = append(stack, abs)
+
+	data, err := readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	docs, err := decodeDocuments(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	var sources []configSource
+	for i, root := range docs {
+		name := path
+		if path == StdioPath {
+			name = "stdin"
+		}
+		if len(docs) > 1 {
+			name = fmt.Sprintf("%s (document %d)", name, i+1)
+		}
+		if root == nil {
+			continue
+		}
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s: expected a mapping at the top level", name)
+		}
+
+		patterns, err := includePatterns(mappingValue(root, "include"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		removeMappingKeys(root, "include")
+		for _, pattern := range patterns {
+			files, err := resolveInclude(filepath.Dir(path), pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			for _, file := range files {
+				// 被 include 的文件总是根据自身的扩展名选择格式
+				fileFormat, _ := DetectFormat(file, "")
+				included, err := loadSources(file, fileFormat, stack, loaded)
+				if err != nil {
+					return nil, err
+				}
+				sources = append(sources, included...)
+			}
+		}
+		sources = append(sources, configSource{name: name, root: root})
+	}
+	return sources, nil
+}
+
+// parseDocuments parses a YAML stream and returns the top-level node of every document.
+// Empty documents are returned as nil so that document numbers match the file.
+func parseDocuments(data []byte) ([]*yaml.Node, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var docs []*yaml.Node
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		if len(doc.Content) == 0 {
+			docs = append(docs, nil)
+			continue
+		}
+		docs = append(docs, doc.Content[0])
+	}
+}
+
+// includePatterns reads the include entry, which is a path or a list of paths.
+func includePatterns(node *yaml.Node) ([]string, error) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}, nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("include: expected a path or a list of paths")
+	}
+
+	patterns := make([]string, 0, len(node.Content))
+	for i, item := range node.Content {
+		if item.Kind != yaml.ScalarNode || item.Value == "" {
+			return nil, fmt.Errorf("include[%d]: expected a path", i)
+		}
+		patterns = append(patterns, item.Value)
+	}
+	return patterns, nil
+}
+
+// resolveInclude 把 include 的路径解析为文件列表。相对路径相对于包含它的文件所在目录
+// （从标准输入读取时为当前目录）；含有 *、? 或 [ 的路径按 glob 匹配，
+// 结果按文件名排序，没有匹配到任何文件时报错。
+func resolveInclude(dir, pattern string) ([]string, error) {
+	path := pattern
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if !strings.ContainsAny(pattern, "*?[") {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("include %s: %w", pattern, err)
+		}
+		return []string{path}, nil
+	}
+
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return nil, fmt.Errorf("include %s: %w", pattern, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("include %s: no files match", pattern)
+	}
+	return matches, nil
+}
+
+// mergeSources 合并所有文档：defaults 按文档顺序合并，profiles、users 和 outputs 汇总到一起。
+// 每个文档的用户在合并 defaults/profile 后单独展开 count 和 matrix，
+// 所有错误都带上所在的文件，用户名重复时同时给出两处来源。
+func mergeSources(sources []configSource) (*types.UserConfig, error) {
+	var defaults *yaml.Node
+	profiles := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	profileSources := make(map[string]string)
+
+	// 先收集所有文档的 defaults 和 profiles，任何文件中的用户都可以引用其他文件定义的 profile
+	for _, src := range sources {
+		if node := mappingValue(src.root, "defaults"); node != nil {
+			if node.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("%s: defaults: expected a mapping", src.name)
+			}
+			markers, err := mergeMarkers(node, "defaults")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", src.name, err)
+			}
+			defaults = mergeNodes(defaults, withoutMappingKeys(node, "merge"), markers, "")
+		}
+
+		node := mappingValue(src.root, "profiles")
+		if node == nil {
+			continue
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s: profiles: expected a mapping of profile name to user fields", src.name)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, profile := node.Content[i].Value, node.Content[i+1]
+			if previous, ok := profileSources[name]; ok {
+				return nil, fmt.Errorf("%s: profile %q is already defined in %s", src.name, name, previous)
+			}
+			if err := checkProfile(name, profile); err != nil {
+				return nil, fmt.Errorf("%s: %w", src.name, err)
+			}
+			profileSources[name] = src.name
+			profiles.Content = append(profiles.Content, node.Content[i], profile)
+		}
+	}
+
+	cfg := &types.UserConfig{}
+	userSources := make(map[string]string)
+	for _, src := range sources {
+		if node := mappingValue(src.root, "outputs"); node != nil {
+			var outputs []types.OutputTarget
+			if err := node.Decode(&outputs); err != nil {
+				return nil, fmt.Errorf("%s: outputs: %w", src.name, err)
+			}
+			cfg.Outputs = append(cfg.Outputs, outputs...)
+		}
+
+		users := mappingValue(src.root, "users")
+		if users == nil {
+			continue
+		}
+		if users.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("%s: users: expected a list", src.name)
+		}
+		if err := resolveProfiles(defaults, profiles, users); err != nil {
+			return nil, fmt.Errorf("%s: %w", src.name, err)
+		}
+		if err := expandUsers(users); err != nil {
+			return nil, fmt.Errorf("%s: %w", src.name, err)
+		}
+
+		for i, node := range users.Content {
+			var user types.UserSpec
+			if err := node.Decode(&user); err != nil {
+				return nil, fmt.Errorf("%s: users[%d]: %w", src.name, i, err)
+			}
+			if user.Username != "" {
+				if previous, ok := userSources[user.Username]; ok {
+					return nil, fmt.Errorf("%s: duplicate username %q, already defined in %s", src.name, user.Username, previous)
+				}
+				userSources[user.Username] = src.name
+			}
+			cfg.Users = append(cfg.Users, user)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes files relative to a temporary directory and returns the directory.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadIncludes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"users.yaml": `
+include:
+  - teams/*.yaml
+  - common.yaml
+defaults:
+  password: Root@123
+users:
+  - username: root-user
+`,
+		"common.yaml": `
+defaults:
+  password: Common@123
+  name: Common
+profiles:
+  bot:
+    name: Bot
+`,
+		"teams/a.yaml": `
+users:
+  - username: a-${index}
+    count: 2
+---
+users:
+  - username: a-bot
+    profile: bot
+`,
+		"teams/b.yaml": `
+include: ../common.yaml
+users:
+  - username: b-user
+`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "users.yaml"), "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var usernames []string
+	for _, user := range cfg.Users {
+		usernames = append(usernames, user.Username)
+	}
+	// Included files come before the file including them; common.yaml is only read once.
+	if got, want := strings.Join(usernames, ","), "a-1,a-2,a-bot,b-user,root-user"; got != want {
+		t.Fatalf("usernames = %s, want %s", got, want)
+	}
+	// The including file's defaults override the included ones and apply to every user.
+	for _, user := range cfg.Users {
+		if user.Password != "Root@123" {
+			t.Errorf("%s password = %q, want Root@123", user.Username, user.Password)
+		}
+	}
+	if cfg.Users[2].Name != "Bot" || cfg.Users[3].Name != "Common" {
+		t.Errorf("names = %q, %q, want Bot, Common", cfg.Users[2].Name, cfg.Users[3].Name)
+	}
+}
+
+func TestLoadIncludeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "duplicate username",
+			files: map[string]string{
+				"users.yaml": "include: team.yaml\nusers:\n  - username: dup\n",
+				"team.yaml":  "users:\n  - username: dup\n",
+			},
+			want: `users.yaml: duplicate username "dup", already defined in `,
+		},
+		{
+			name: "error names the document",
+			files: map[string]string{
+				"users.yaml": "users: []\n---\nusers:\n  - username: u\n    profile: missing\n",
+			},
+			want: `users.yaml (document 2): users[0].profile: unknown profile "missing"`,
+		},
+		{
+			name: "glob without matches",
+			files: map[string]string{
+				"users.yaml": "include: teams/*.yaml\n",
+			},
+			want: "include teams/*.yaml: no files match",
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"users.yaml": "include: other.yaml\n",
+				"other.yaml": "include: users.yaml\n",
+			},
+			want: "include cycle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			_, err := Load(filepath.Join(dir, "users.yaml"), "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
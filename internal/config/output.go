@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab-cli-sdk/internal/utils"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// ParseOutputTarget 解析 --output 的值 path[:template[:mode]]。
+// template 可以是模板文件或内置格式名称，mode 是八进制文件权限，两者都可以留空，
+// 例如 output.yaml、secret.yaml:k8s-secret、ci.env:ci.tpl:0600、output.json::0600。
+// template 和 mode 从右侧切分，最后一段不是空值或八进制权限时只切出 template，
+// 因此路径中可以包含冒号，例如 C:\out\output.yaml:dotenv；
+// 没有 template 的路径包含冒号时需要以 :: 结尾，例如 out-10:30.yaml::
+func ParseOutputTarget(spec string) (types.OutputTarget, error) {
+	path := spec
+	var cuts []string
+	for len(cuts) < 2 {
+		i := strings.LastIndex(path, ":")
+		if i < 0 || isDrive(path, i) {
+			break
+		}
+		cuts = append(cuts, path[i+1:])
+		path = path[:i]
+	}
+
+	target := types.OutputTarget{Path: path}
+	switch {
+	case len(cuts) == 2 && isModeField(cuts[0]):
+		target.Template, target.Mode = cuts[1], cuts[0]
+	case len(cuts) == 2:
+		// 最后一段不是权限，倒数第二个冒号属于路径
+		target.Path, target.Template = path+":"+cuts[1], cuts[0]
+	case len(cuts) == 1:
+		target.Template = cuts[0]
+	}
+	if target.Path == "" {
+		return types.OutputTarget{}, fmt.Errorf("invalid output %q: path is empty", spec)
+	}
+	return target, nil
+}
+
+// isDrive 判断 path 中位置 i 的冒号是否属于 C:\ 或 C:/ 这样的 Windows 盘符
+func isDrive(path string, i int) bool {
+	if i != 1 || len(path) < 3 || (path[2] != '\\' && path[2] != '/') {
+		return false
+	}
+	c := path[0]
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// isModeField 判断 s 是否可以作为 mode 字段：为空或是八进制文件权限
+func isModeField(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, err := utils.ParseFileMode(s)
+	return err == nil
+}
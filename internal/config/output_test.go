@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+func TestParseOutputTarget(t *testing.T) {
+	tests := []struct {
+		spec string
+		want types.OutputTarget
+	}{
+		{spec: "output.yaml", want: types.OutputTarget{Path: "output.yaml"}},
+		{spec: "secret.yaml:k8s-secret", want: types.OutputTarget{Path: "secret.yaml", Template: "k8s-secret"}},
+		{spec: "ci.env:ci.tpl:0600", want: types.OutputTarget{Path: "ci.env", Template: "ci.tpl", Mode: "0600"}},
+		{spec: "output.json::0600", want: types.OutputTarget{Path: "output.json", Mode: "0600"}},
+		{spec: `C:\out\output.yaml`, want: types.OutputTarget{Path: `C:\out\output.yaml`}},
+		{spec: `C:\out\ci.env:dotenv`, want: types.OutputTarget{Path: `C:\out\ci.env`, Template: "dotenv"}},
+		{spec: "C:/out/ci.env:ci.tpl:0640", want: types.OutputTarget{Path: "C:/out/ci.env", Template: "ci.tpl", Mode: "0640"}},
+		{spec: "a:dotenv", want: types.OutputTarget{Path: "a", Template: "dotenv"}},
+		{spec: "out-10:30:00.env:dotenv", want: types.OutputTarget{Path: "out-10:30:00.env", Template: "dotenv"}},
+		{spec: "out-10:30:00.yaml::", want: types.OutputTarget{Path: "out-10:30:00.yaml"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseOutputTarget(tt.spec)
+		if err != nil {
+			t.Errorf("ParseOutputTarget(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseOutputTarget(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"", ":dotenv", "::0600"} {
+		if _, err := ParseOutputTarget(spec); err == nil {
+			t.Errorf("ParseOutputTarget(%q) error = nil, want an error", spec)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// List merge modes selected per key with the merge: marker.
+const (
+	mergeReplace = "replace"
+	mergeAppend  = "append"
+	mergePrepend = "prepend"
+)
+
+// resolveProfiles 把 defaults 和用户引用的 profile 合并进 users 中的每个用户。
+//
+// 合并顺序为 defaults → profile → 用户自身，后者覆盖前者：
+//   - mapping（例如 token）逐个字段合并，只覆盖后者写出的字段
+//   - 标量直接覆盖；写 null 可以清除继承的值
+//   - 列表默认整体替换；在同一层写 merge: {groups: append} 可改为追加到继承的列表之后，
+//     prepend 插入到之前，嵌套字段使用点号路径，例如 merge: {token.scope: append}
+//
+// defaults 和 profiles 可以为 nil。
+func resolveProfiles(defaults, profiles, users *yaml.Node) error {
+	for i, user := range users.Content {
+		path := fmt.Sprintf("users[%d]", i)
+		if user.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s: expected a mapping", path)
+		}
+
+		var layers []*yaml.Node
+		var layerPaths []string
+		if defaults != nil {
+			layers = append(layers, defaults)
+			layerPaths = append(layerPaths, "defaults")
+		}
+		if profileNode := mappingValue(user, "profile"); profileNode != nil {
+			profile := mappingValue(profiles, profileNode.Value)
+			if profile == nil {
+				return fmt.Errorf("%s.profile: unknown profile %q", path, profileNode.Value)
+			}
+			layers = append(layers, profile)
+			layerPaths = append(layerPaths, "profiles."+profileNode.Value)
+		}
+		layers = append(layers, user)
+		layerPaths = append(layerPaths, path)
+
+		var merged *yaml.Node
+		for j, layer := range layers {
+			markers, err := mergeMarkers(layer, layerPaths[j])
+			if err != nil {
+				return err
+			}
+			layer = withoutMappingKeys(layer, "profile", "merge")
+			merged = mergeNodes(merged, layer, markers, "")
+		}
+		users.Content[i] = merged
+	}
+	return nil
+}
+
+// checkProfile reports a profile that cannot be merged into users.
+func checkProfile(name string, profile *yaml.Node) error {
+	path := "profiles." + name
+	if profile.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: expected a mapping", path)
+	}
+	if mappingValue(profile, "profile") != nil {
+		return fmt.Errorf("%s: profiles cannot reference another profile", path)
+	}
+	_, err := mergeMarkers(profile, path)
+	return err
+}
+
+// mergeMarkers reads the merge: marker of a layer, mapping dotted field paths to merge modes.
+func mergeMarkers(layer *yaml.Node, path string) (map[string]string, error) {
+	node := mappingValue(layer, "merge")
+	if node == nil {
+		return nil, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s.merge: expected a mapping of field to %s, %s or %s", path, mergeReplace, mergeAppend, mergePrepend)
+	}
+
+	markers := make(map[string]string, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		field, mode := node.Content[i].Value, node.Content[i+1].Value
+		switch mode {
+		case mergeReplace, mergeAppend, mergePrepend:
+			markers[field] = mode
+		default:
+			return nil, fmt.Errorf("%s.merge.%s: unknown mode %q (want %s, %s or %s)", path, field, mode, mergeReplace, mergeAppend, mergePrepend)
+		}
+	}
+	return markers, nil
+}
+
+// mergeNodes returns a new node with over merged onto base; neither input is modified.
+func mergeNodes(base, over *yaml.Node, markers map[string]string, path string) *yaml.Node {
+	if base == nil {
+		return copyNode(over)
+	}
+
+	switch {
+	case base.Kind == yaml.MappingNode && over.Kind == yaml.MappingNode:
+		merged := copyNode(base)
+		for i := 0; i+1 < len(over.Content); i += 2 {
+			key, value := over.Content[i], over.Content[i+1]
+			fieldPath := key.Value
+			if path != "" {
+				fieldPath = path + "." + key.Value
+			}
+			if j := mappingIndex(merged, key.Value); j >= 0 {
+				merged.Content[j+1] = mergeNodes(merged.Content[j+1], value, markers, fieldPath)
+			} else {
+				merged.Content = append(merged.Content, copyNode(key), copyNode(value))
+			}
+		}
+		return merged
+
+	case base.Kind == yaml.SequenceNode && over.Kind == yaml.SequenceNode:
+		merged := copyNode(over)
+		switch markers[path] {
+		case mergeAppend:
+			merged.Content = append(copyNode(base).Content, merged.Content...)
+		case mergePrepend:
+			merged.Content = append(merged.Content, copyNode(base).Content...)
+		}
+		return merged
+	}
+
+	return copyNode(over)
+}
+
+// copyNode returns a deep copy of node.
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	if node.Content != nil {
+		copied.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			copied.Content[i] = copyNode(child)
+		}
+	}
+	return &copied
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1.
+func mappingIndex(node *yaml.Node, key string) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// withoutMappingKeys returns a shallow copy of a mapping node without the given keys.
+func withoutMappingKeys(node *yaml.Node, keys ...string) *yaml.Node {
+	copied := *node
+	copied.Content = nil
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !containsString(keys, node.Content[i].Value) {
+			copied.Content = append(copied.Content, node.Content[i], node.Content[i+1])
+		}
+	}
+	return &copied
+}
+
+// removeMappingKeys removes the given keys from a mapping node in place.
+func removeMappingKeys(node *yaml.Node, keys ...string) {
+	*node = *withoutMappingKeys(node, keys...)
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+func TestLoadResolvesProfiles(t *testing.T) {
+	path := writeConfig(t, `
+defaults:
+  password: Default@123
+  token:
+    scope: [read_api]
+    expires_at: "2030-01-01"
+  groups:
+    - name: shared
+profiles:
+  ci-bot:
+    name: CI Bot
+    token:
+      scope: [api]
+users:
+  - username: bot-${index}
+    email: bot-${index}@test.example.com
+    profile: ci-bot
+    count: 2
+    groups:
+      - name: ci
+    merge:
+      groups: append
+  - username: plain
+    email: plain@test.example.com
+    password: Plain@123
+    groups:
+      - name: own
+`)
+
+	cfg, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Defaults != nil || cfg.Profiles != nil {
+		t.Errorf("defaults and profiles should be removed after Load")
+	}
+	if len(cfg.Users) != 3 {
+		t.Fatalf("len(Users) = %d, want 3", len(cfg.Users))
+	}
+
+	bot := cfg.Users[1]
+	if bot.Username != "bot-2" || bot.Name != "CI Bot" || bot.Password != "Default@123" {
+		t.Errorf("Users[1] = %+v, want bot-2 with the profile name and default password", bot)
+	}
+	if bot.Profile != "" || bot.Merge != nil {
+		t.Errorf("profile and merge markers should be removed, got %q %v", bot.Profile, bot.Merge)
+	}
+	// The profile replaces token.scope but keeps the default expires_at.
+	if bot.Token == nil || strings.Join(bot.Token.Scope, ",") != "api" || bot.Token.ExpiresAt != "2030-01-01" {
+		t.Errorf("Users[1].Token = %+v, want scope [api] expiring 2030-01-01", bot.Token)
+	}
+	if got := groupNames(bot.Groups); got != "shared,ci" {
+		t.Errorf("Users[1].Groups = %s, want shared,ci", got)
+	}
+
+	plain := cfg.Users[2]
+	if plain.Password != "Plain@123" {
+		t.Errorf("Users[2].Password = %q, want the user's own password", plain.Password)
+	}
+	if got := groupNames(plain.Groups); got != "own" {
+		t.Errorf("Users[2].Groups = %s, want own (lists are replaced by default)", got)
+	}
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "unknown profile",
+			content: "users:\n  - username: a\n    profile: missing\n",
+			want:    `users[0].profile: unknown profile "missing"`,
+		},
+		{
+			name:    "unknown merge mode",
+			content: "users:\n  - username: a\n    merge:\n      groups: union\n",
+			want:    `users[0].merge.groups: unknown mode "union"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.content), "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// groupNames joins the names of groups with commas.
+func groupNames(groups []types.GroupSpec) string {
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return strings.Join(names, ",")
+}
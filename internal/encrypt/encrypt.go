@@ -0,0 +1,278 @@
+// Package encrypt 使用 age（X25519 公钥或口令）在本地加密和解密输出文件，不依赖外部 KMS。
+//
+// 加密有两种方式：整个文件加密为 ASCII armor 格式；或只加密密码和 Token 字段（类似 SOPS），
+// 字段值替换为 ENC[age:...]，文件其余部分保持可读。
+// 读取配置和输出结果的代码通过 Decrypt 和 DecryptNodes 透明解密，使用的身份由 cli 启动时
+// 通过 LoadIdentities 登记。
+package encrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"gitlab-cli-sdk/pkg/types"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"gopkg.in/yaml.v3"
+)
+
+// PassphraseEnv 是口令加密和解密使用的环境变量，口令不通过命令行参数传入，避免出现在进程列表中
+const PassphraseEnv = "GITLAB_CLI_PASSPHRASE"
+
+// PassphraseRecipient 作为 --encrypt-to 的值时表示使用 PassphraseEnv 中的口令加密
+const PassphraseRecipient = "passphrase"
+
+// 加密字段的前缀和后缀，例如 ENC[age:YWdlLWVuY3J5cHRpb24ub3JnL3Yx...]
+const (
+	fieldPrefix = "ENC[age:"
+	fieldSuffix = "]"
+)
+
+// binaryHeader 是未使用 armor 的 age 文件的第一行
+const binaryHeader = "age-encryption.org/v1\n"
+
+// scryptWorkFactor 是口令加密的 scrypt 强度，测试中调低以加快速度
+var scryptWorkFactor = 18
+
+var (
+	mu         sync.RWMutex
+	identities []age.Identity
+)
+
+// ParseRecipients 解析 --encrypt-to 的值：age1 开头的 X25519 公钥，或 PassphraseRecipient。
+// age 不允许口令和公钥混用
+func ParseRecipients(specs []string) ([]age.Recipient, error) {
+	var recipients []age.Recipient
+	for _, spec := range specs {
+		if spec != PassphraseRecipient {
+			recipient, err := age.ParseX25519Recipient(spec)
+			if err != nil {
+				return nil, fmt.Errorf("invalid recipient %q: %w", spec, err)
+			}
+			recipients = append(recipients, recipient)
+			continue
+		}
+
+		if len(specs) > 1 {
+			return nil, fmt.Errorf("recipient %s cannot be combined with other recipients", PassphraseRecipient)
+		}
+		passphrase := os.Getenv(PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("recipient %s requires the %s env", PassphraseRecipient, PassphraseEnv)
+		}
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		recipient.SetWorkFactor(scryptWorkFactor)
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// LoadIdentities 读取身份文件（age-keygen 或 gitlab-cli keygen 生成的私钥文件）并登记为解密身份；
+// 设置了 PassphraseEnv 时同时登记口令身份
+func LoadIdentities(files []string) error {
+	var loaded []age.Identity
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read identity file: %w", err)
+		}
+		ids, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("parse identity file %s: %w", file, err)
+		}
+		loaded = append(loaded, ids...)
+	}
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return err
+		}
+		loaded = append(loaded, identity)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	identities = loaded
+	return nil
+}
+
+// GenerateIdentity 生成新的 X25519 密钥对，返回私钥文件内容和对应的公钥
+func GenerateIdentity() (identity, recipient string, err error) {
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", err
+	}
+	return key.String() + "\n", key.Recipient().String(), nil
+}
+
+// Encrypt 加密整个文件，结果使用 ASCII armor，可以直接提交到代码仓库
+func Encrypt(data []byte, recipients []age.Recipient) ([]byte, error) {
+	var buf bytes.Buffer
+	armored := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armored, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := armored.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// IsEncrypted 判断 data 是否为 age 加密的文件（armor 或二进制格式）
+func IsEncrypted(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return bytes.HasPrefix(trimmed, []byte(armor.Header)) || bytes.HasPrefix(data, []byte(binaryHeader))
+}
+
+// Decrypt 使用已登记的身份解密整个文件，未加密的数据原样返回
+func Decrypt(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	var r io.Reader = bytes.NewReader(data)
+	if !bytes.HasPrefix(data, []byte(binaryHeader)) {
+		r = armor.NewReader(bytes.NewReader(bytes.TrimLeft(data, " \t\r\n")))
+	}
+	decrypted, err := decrypt(r)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(decrypted)
+}
+
+func decrypt(r io.Reader) (io.Reader, error) {
+	mu.RLock()
+	ids := identities
+	mu.RUnlock()
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("data is encrypted: use --identity or set %s", PassphraseEnv)
+	}
+	decrypted, err := age.Decrypt(r, ids...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("data is encrypted for a different key or passphrase")
+		}
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return decrypted, nil
+}
+
+// EncryptValue 加密单个字段，返回 ENC[age:...] 形式的字符串
+func EncryptValue(value string, recipients []age.Recipient) (string, error) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(w, value); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return fieldPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()) + fieldSuffix, nil
+}
+
+// IsEncryptedValue 判断字段值是否为 EncryptValue 的结果
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, fieldPrefix) && strings.HasSuffix(value, fieldSuffix)
+}
+
+// DecryptValue 解密 EncryptValue 加密的字段，未加密的值原样返回
+func DecryptValue(value string) (string, error) {
+	if !IsEncryptedValue(value) {
+		return value, nil
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, fieldPrefix), fieldSuffix))
+	if err != nil {
+		return "", fmt.Errorf("decode encrypted value: %w", err)
+	}
+	decrypted, err := decrypt(bytes.NewReader(ciphertext))
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := io.ReadAll(decrypted)
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// EncryptOutput 返回输出结果的副本，其中的密码和 Token 被 EncryptValue 加密，原结果不变
+func EncryptOutput(output *types.OutputConfig, recipients []age.Recipient) (*types.OutputConfig, error) {
+	encrypted := *output
+	encrypted.Users = make([]types.UserOutput, len(output.Users))
+	for i, user := range output.Users {
+		if user.Password != "" {
+			value, err := EncryptValue(user.Password, recipients)
+			if err != nil {
+				return nil, fmt.Errorf("encrypt password of %s: %w", user.Username, err)
+			}
+			user.Password = value
+		}
+		if user.Token != nil && user.Token.Value != "" {
+			value, err := EncryptValue(user.Token.Value, recipients)
+			if err != nil {
+				return nil, fmt.Errorf("encrypt token of %s: %w", user.Username, err)
+			}
+			token := *user.Token
+			token.Value = value
+			user.Token = &token
+		}
+		encrypted.Users[i] = user
+	}
+	return &encrypted, nil
+}
+
+// DecryptNodes 解密文档中所有 ENC[age:...] 字段。在 YAML 节点上处理，因此对 YAML、JSON 和 TOML
+// 以及配置文件和输出结果都适用
+func DecryptNodes(nodes []*yaml.Node) error {
+	for _, node := range nodes {
+		if err := decryptNode(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decryptNode(node *yaml.Node) error {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode && IsEncryptedValue(node.Value) {
+		value, err := DecryptValue(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		node.Value = value
+		node.Tag = "!!str"
+		node.Style = 0
+		return nil
+	}
+	for _, child := range node.Content {
+		if err := decryptNode(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package encrypt
+
+import (
+	"strings"
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+
+	"filippo.io/age"
+	"gopkg.in/yaml.v3"
+)
+
+// useKey generates a key pair, registers the identity and returns the recipients.
+func useKey(t *testing.T) []age.Recipient {
+	t.Helper()
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	identities = []age.Identity{key}
+	t.Cleanup(func() { identities = nil })
+	return []age.Recipient{key.Recipient()}
+}
+
+func TestEncryptFile(t *testing.T) {
+	recipients := useKey(t)
+
+	encrypted, err := Encrypt([]byte("password: secret\n"), recipients)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(string(encrypted), "secret") {
+		t.Fatalf("Encrypt() = %q, want an armored age file", encrypted)
+	}
+	decrypted, err := Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(decrypted) != "password: secret\n" {
+		t.Errorf("Decrypt() = %q", decrypted)
+	}
+
+	plain, err := Decrypt([]byte("users: []\n"))
+	if err != nil || string(plain) != "users: []\n" {
+		t.Errorf("Decrypt(plaintext) = %q, %v", plain, err)
+	}
+
+	identities = nil
+	if _, err := Decrypt(encrypted); err == nil || !strings.Contains(err.Error(), "--identity") {
+		t.Errorf("Decrypt() without identity error = %v", err)
+	}
+}
+
+func TestEncryptFields(t *testing.T) {
+	recipients := useKey(t)
+
+	output := &types.OutputConfig{Users: []types.UserOutput{
+		{Username: "bot", Email: "bot@example.com", Password: "Passw0rd!", Token: &types.TokenOutput{Value: "glpat-abc"}},
+	}}
+	encrypted, err := EncryptOutput(output, recipients)
+	if err != nil {
+		t.Fatalf("EncryptOutput() error = %v", err)
+	}
+	user := encrypted.Users[0]
+	if !IsEncryptedValue(user.Password) || !IsEncryptedValue(user.Token.Value) || user.Email != "bot@example.com" {
+		t.Fatalf("EncryptOutput() user = %+v", user)
+	}
+	if output.Users[0].Password != "Passw0rd!" || output.Users[0].Token.Value != "glpat-abc" {
+		t.Errorf("EncryptOutput() modified the original: %+v", output.Users[0])
+	}
+
+	data, err := yaml.Marshal(encrypted)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := DecryptNodes([]*yaml.Node{&doc}); err != nil {
+		t.Fatalf("DecryptNodes() error = %v", err)
+	}
+	var decrypted types.OutputConfig
+	if err := doc.Decode(&decrypted); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := decrypted.Users[0]; got.Password != "Passw0rd!" || got.Token.Value != "glpat-abc" {
+		t.Errorf("decrypted user = %+v", got)
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	scryptWorkFactor = 10
+	t.Setenv(PassphraseEnv, "correct horse battery staple")
+	t.Cleanup(func() { identities = nil })
+
+	recipients, err := ParseRecipients([]string{PassphraseRecipient})
+	if err != nil {
+		t.Fatalf("ParseRecipients() error = %v", err)
+	}
+	encrypted, err := Encrypt([]byte("token"), recipients)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if err := LoadIdentities(nil); err != nil {
+		t.Fatalf("LoadIdentities() error = %v", err)
+	}
+	decrypted, err := Decrypt(encrypted)
+	if err != nil || string(decrypted) != "token" {
+		t.Errorf("Decrypt() = %q, %v", decrypted, err)
+	}
+
+	if _, err := ParseRecipients([]string{PassphraseRecipient, "age1xyz"}); err == nil {
+		t.Error("ParseRecipients() with passphrase and a key should fail")
+	}
+}
@@ -0,0 +1,152 @@
+// Package events 以 NDJSON 格式输出资源操作事件，供流水线中的其他工具在资源创建或删除时
+// 立即做出反应，而不必解析日志或等待最终的输出结果。
+//
+// 每个事件是一行 JSON 对象，事件中不包含密码和 Token，错误信息中已登记的凭证也会被隐藏。
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/utils"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Type 是事件类型
+type Type string
+
+const (
+	// TypeStart 表示开始处理一次运行或一个用户
+	TypeStart Type = "start"
+	// TypeCreate 表示资源已创建
+	TypeCreate Type = "create"
+	// TypeExists 表示要创建的资源已存在，或复用了上次运行创建的资源
+	TypeExists Type = "exists"
+	// TypeUpdate 表示已有资源被修改；目前的创建和清理流程不修改已有资源，保留给后续使用
+	TypeUpdate Type = "update"
+	// TypeDelete 表示资源已删除
+	TypeDelete Type = "delete"
+	// TypeVerify 表示等待 GitLab 完成异步删除的验证结束（超时时带有 error），
+	// 或 verify 命令检查完一个资源
+	TypeVerify Type = "verify"
+	// TypeError 表示资源操作失败
+	TypeError Type = "error"
+)
+
+// KindRun 是运行级事件的资源类型
+const KindRun result.Kind = "run"
+
+// Event 是一个资源操作事件
+type Event struct {
+	Time   time.Time     `json:"time"`
+	RunID  string        `json:"run_id,omitempty"`
+	Type   Type          `json:"type"`
+	Action result.Action `json:"action,omitempty"` // 事件所属的操作：create、delete 或 verify
+	Kind   result.Kind   `json:"kind"`
+	User   string        `json:"user,omitempty"` // 资源所属的用户名
+	Name   string        `json:"name,omitempty"` // 配置文件中的名称
+	ID     int           `json:"id,omitempty"`   // GitLab 中的资源 ID
+	Path   string        `json:"path,omitempty"` // 组 path 或项目 full path
+	WebURL string        `json:"web_url,omitempty"`
+	// Duration 是操作耗时，单位为秒
+	Duration float64 `json:"duration,omitempty"`
+	// Error 是失败原因，Status 是 GitLab API 返回的 HTTP 状态码
+	Error  string `json:"error,omitempty"`
+	Status int    `json:"status,omitempty"`
+
+	// Started 不为零时由 Emit 计算 Duration
+	Started time.Time `json:"-"`
+	// Err 不为空时由 Emit 填充 Error 和 Status
+	Err error `json:"-"`
+}
+
+// Stream 把事件逐行写入文件或标准输出，可在多个 goroutine 中并发使用。
+// nil *Stream 丢弃所有事件
+type Stream struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	runID  string
+	failed bool
+}
+
+// Open 打开事件输出，path 为 - 时写到标准输出，否则追加写入文件（权限 0600）。
+// runID 写入每个事件，便于区分追加到同一文件的多次运行
+func Open(path, runID string) (*Stream, error) {
+	if path == utils.StdioPath {
+		return &Stream{w: os.Stdout, runID: runID}, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open events file: %w", err)
+	}
+	return &Stream{w: file, closer: file, runID: runID}, nil
+}
+
+// New 创建写入 w 的事件流
+func New(w io.Writer, runID string) *Stream {
+	return &Stream{w: w, runID: runID}
+}
+
+// Emit 写入一个事件。写入失败只记录一次警告，不影响资源操作
+func (s *Stream) Emit(e Event) {
+	if s == nil {
+		return
+	}
+	e.Time = time.Now().UTC()
+	e.RunID = s.runID
+	if !e.Started.IsZero() {
+		e.Duration = time.Since(e.Started).Round(time.Millisecond).Seconds()
+	}
+	if e.Err != nil {
+		e.Error = redact.String(errorMessage(e.Err))
+		e.Status = statusCode(e.Err)
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(line, '\n')); err != nil && !s.failed {
+		s.failed = true
+		slog.Warn(i18n.T("写入事件失败"), "error", err)
+	}
+}
+
+// Close 关闭事件文件
+func (s *Stream) Close() error {
+	if s == nil || s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// errorMessage 去掉 ResourceError 中与事件字段重复的类型和名称
+func errorMessage(err error) string {
+	var resErr *result.ResourceError
+	if errors.As(err, &resErr) {
+		return resErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// statusCode 返回 GitLab API 错误的 HTTP 状态码，其他错误返回 0
+func statusCode(err error) int {
+	var apiErr *gitlab.ErrorResponse
+	if errors.As(err, &apiErr) && apiErr.Response != nil {
+		return apiErr.Response.StatusCode
+	}
+	return 0
+}
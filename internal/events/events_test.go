@@ -0,0 +1,57 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestEmit(t *testing.T) {
+	var buf bytes.Buffer
+	stream := New(&buf, "20261018100000000-a1b2")
+
+	stream.Emit(Event{Type: TypeCreate, Action: result.ActionCreate, Kind: result.KindProject, User: "bot", Name: "demo", ID: 42, Path: "bot/demo", Started: time.Now().Add(-1500 * time.Millisecond)})
+
+	redact.Add("glpat-secret-value")
+	req, _ := http.NewRequest(http.MethodPost, "https://gitlab.example.com/api/v4/groups", nil)
+	apiErr := &gitlab.ErrorResponse{Response: &http.Response{StatusCode: http.StatusConflict, Request: req}, Message: "token glpat-secret-value taken"}
+	err := &result.ResourceError{Kind: result.KindGroup, Name: "team", Action: result.ActionCreate, Err: apiErr}
+	stream.Emit(Event{Type: TypeError, Action: result.ActionCreate, Kind: result.KindGroup, User: "bot", Path: "team", Err: err})
+
+	var nilStream *Stream
+	nilStream.Emit(Event{Type: TypeStart})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	var created map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &created); err != nil {
+		t.Fatalf("unmarshal %s: %v", lines[0], err)
+	}
+	for key, want := range map[string]any{"type": "create", "run_id": "20261018100000000-a1b2", "kind": "project", "id": 42.0, "path": "bot/demo", "duration": 1.5} {
+		if created[key] != want {
+			t.Errorf("create event %s = %v, want %v", key, created[key], want)
+		}
+	}
+
+	var failed Event
+	if err := json.Unmarshal([]byte(lines[1]), &failed); err != nil {
+		t.Fatalf("unmarshal %s: %v", lines[1], err)
+	}
+	if failed.Status != http.StatusConflict {
+		t.Errorf("error event status = %d, want %d", failed.Status, http.StatusConflict)
+	}
+	if strings.Contains(failed.Error, "glpat-secret-value") || strings.HasPrefix(failed.Error, "create group") {
+		t.Errorf("error event error = %q, want the API message with secrets hidden", failed.Error)
+	}
+}
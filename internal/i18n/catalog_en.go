@@ -0,0 +1,476 @@
+package i18n
+
+// en 是英文目录，键为代码中的中文原文
+var en = map[string]string{
+	// cmd/gitlab-cli/main.go
+	"命令执行失败": "command failed",
+
+	// internal/audit/audit.go
+	"写入审计日志失败": "failed to write audit log",
+
+	// internal/cli/audit.go
+	"审计日志": "audit log",
+	"无法打开默认审计日志，本次运行不记录审计日志": "cannot open the default audit log; this run is not journaled",
+	"审计日志相关命令":  "Audit log commands",
+	"查看和筛选审计日志": "Show and filter the audit log",
+	`列出审计日志中记录的修改性 API 调用（创建和删除用户、组、项目和 Token 等），
+可以按运行 ID、调用者、sudo 用户、操作、目标、时间和结果筛选。
+--since 和 --until 接受 RFC 3339 时间、YYYY-MM-DD 日期，或 24h 这样表示多久之前的时长。
+
+示例:
+  gitlab-cli audit show --since 24h
+  gitlab-cli audit show --run-id 20251030150000123-a1b2 --failed
+  gitlab-cli audit show --operation DeleteUser --actor root --json`: `List the mutating API calls recorded in the audit log (creating and deleting users, groups, projects, tokens and so on),
+filtered by run ID, actor, sudo user, operation, target, time and outcome.
+--since and --until accept an RFC 3339 time, a YYYY-MM-DD date, or a duration such as 24h meaning that long ago.
+
+Examples:
+  gitlab-cli audit show --since 24h
+  gitlab-cli audit show --run-id 20251030150000123-a1b2 --failed
+  gitlab-cli audit show --operation DeleteUser --actor root --json`,
+	"只显示该运行 ID 的调用":                    "Only show calls made by this run ID",
+	"只显示该调用者（Token 所属用户）的调用":           "Only show calls made by this actor (the token owner)",
+	"只显示以该用户身份（sudo）发出的调用":             "Only show calls made on behalf of this user (sudo)",
+	"只显示该操作，例如 CreateUser、DeleteGroup": "Only show this operation, e.g. CreateUser, DeleteGroup",
+	"只显示目标匹配的调用：user:alice 和 user:7 都匹配用户 alice（ID 7）的创建和删除，其余值按子串匹配": "Only show calls matching this target: user:alice and user:7 both match the create and delete calls of user alice (ID 7); other values match as a substring",
+	"只显示该时间之后的调用":       "Only show calls made at or after this time",
+	"只显示该时间之前的调用":       "Only show calls made before this time",
+	"只显示失败的调用":          "Only show failed calls",
+	"以 NDJSON 格式输出原始记录": "Print the raw records as NDJSON",
+	"审计记录":              "audit records",
+	"无法解析时间 %q（应为 RFC 3339 时间、YYYY-MM-DD 日期或 24h 这样的时长）": "cannot parse time %q (want an RFC 3339 time, a YYYY-MM-DD date or a duration such as 24h)",
+
+	// internal/cli/cmd.go
+	"GitLab 用户和项目自动化管理工具（使用 GitLab Go SDK）": "Automated GitLab user and project management (using the GitLab Go SDK)",
+	`GitLab CLI 是基于官方 GitLab Go SDK 的用户和项目自动化管理工具。
+它通过 YAML 配置文件批量创建和管理 GitLab 用户、组和项目。
+
+特性：
+  - 使用官方 GitLab Go SDK (gitlab.com/gitlab-org/api/client-go)
+  - 无需外部依赖，纯 Go 实现
+  - 类型安全的 API 调用
+  - 更好的性能和错误处理
+
+前置要求：
+  - GitLab 管理员权限的 Personal Access Token (api + sudo scopes)
+
+退出码：
+  0  全部成功
+  1  配置、认证等错误，或操作被取消
+  2  部分资源操作失败
+  3  全部资源操作失败`: `GitLab CLI is an automated user and project management tool built on the official GitLab Go SDK.
+It creates and manages GitLab users, groups and projects in bulk from a YAML config file.
+
+Features:
+  - Uses the official GitLab Go SDK (gitlab.com/gitlab-org/api/client-go)
+  - No external dependencies, pure Go
+  - Type-safe API calls
+  - Better performance and error handling
+
+Prerequisites:
+  - A Personal Access Token with GitLab admin rights (api + sudo scopes)
+
+Exit codes:
+  0  everything succeeded
+  1  config or authentication error, or the operation was canceled
+  2  some resource operations failed
+  3  all resource operations failed`,
+	"在日志中显示密码和 Token（默认隐藏）":         "Show passwords and tokens in logs (hidden by default)",
+	"日志级别: debug、info、warn 或 error": "Log level: debug, info, warn or error",
+	"日志格式: text 或 json":             "Log format: text or json",
+	"日志追加写入的文件（默认输出到标准错误）":          "File to append logs to (default: standard error)",
+	"追加记录修改性 API 调用的审计日志（为空时不记录，默认路径可通过 %s 环境变量修改）": "Audit log that records every mutating API call (empty disables it; the default path can be changed with %s)",
+	"消息语言: zh 或 en（默认根据 LANG 判断）":                   "Message language: zh or en (default: derived from LANG)",
+	"解密配置和输出结果使用的 age 私钥文件，可重复指定（口令通过 %s 环境变量提供）":   "age identity file used to decrypt configs and outputs, repeatable (passphrases are read from the %s env)",
+	"用户管理命令":                     "User management commands",
+	"配置文件相关命令":                   "Config file commands",
+	"输出展开 count 和 matrix 后的完整配置": "Print the full config with count and matrix expanded",
+	`读取配置文件，展开 count 和 matrix 并替换 ${index} 等变量后输出到标准输出，
+默认为 YAML 格式，可用 --format 输出 JSON 或 TOML。
+不连接 GitLab，可用于在创建前检查展开结果。
+
+示例:
+  gitlab-cli config expand -f config.yaml
+  gitlab-cli config expand -f config.toml --format json`: `Read the config file, expand count and matrix, substitute variables such as ${index}
+and print the result to standard output, as YAML by default or as JSON or TOML with --format.
+Does not connect to GitLab, so it can be used to check the expansion before creating anything.
+
+Examples:
+  gitlab-cli config expand -f config.yaml
+  gitlab-cli config expand -f config.toml --format json`,
+	"配置文件路径（- 表示标准输入）":                     "Config file path (- for standard input)",
+	"输出格式: yaml（默认）、json 或 toml":           "Output format: yaml (default), json or toml",
+	"根据配置文件创建用户、组和项目":                      "Create users, groups and projects from a config file",
+	"GitLab 主机地址":                          "GitLab host URL",
+	"GitLab 个人访问令牌（Personal Access Token）": "GitLab Personal Access Token",
+	"GitLab SSH 地址（例如 ssh://git@host:22）":  "GitLab SSH endpoint (e.g., ssh://git@host:22)",
+	"输出目标 path[:template[:mode]]，可重复指定；template 为模板文件或内置格式，省略时按扩展名输出 YAML、JSON 或 TOML（- 表示标准输出）；路径包含冒号且没有 template 时以 :: 结尾": "Output target path[:template[:mode]], repeatable; template is a template file or built-in format, without one the output is YAML, JSON or TOML by extension (- for standard output); end a path that contains a colon with :: when there is no template",
+	"使用模板文件格式化未指定模板的 --output":                              "Template file used for an --output without a template",
+	"模板严格模式：访问不存在的键或未设置的环境变量时报错":                            "Strict template mode: fail on missing keys or unset environment variables",
+	"输出文件中的密码和 Token 替换为 ${<USERNAME>_TOKEN} 形式的引用（内置格式除外）": "Replace passwords and tokens in output files with references like ${<USERNAME>_TOKEN} (except built-in formats)",
+	"只加密原始结果中的密码和 Token 字段，其余内容保持可读（模板和内置格式仍加密整个文件）":        "Encrypt only password and token fields of raw outputs and keep the rest readable (templates and built-in formats are still encrypted whole)",
+	"使用内置格式输出未指定模板的 --output: %s":                           "Built-in format for an --output without a template: %s",
+	"prefix 模式下附加在毫秒时间戳之后的自定义后缀":                            "Custom suffix appended after millisecond timestamp in prefix mode",
+	"本次运行的 ID，prefix 模式生成的名称都以它结尾（默认由毫秒时间戳和后缀生成）":           "ID of this run that ends every name generated in prefix mode (default: millisecond timestamp and suffix)",
+	"断点文件路径，每完成一个资源就更新一次":                                   "Checkpoint file path, updated after every finished resource",
+	"从断点文件恢复中断的创建流程（复用已生成的名称并跳过已完成的用户）":                     "Resume an interrupted create from the checkpoint file (reusing generated names and skipping finished users)",
+	"清理配置文件中定义的用户":                                          "Clean up the users defined in a config file",
+	`清理配置文件中定义的用户及其所有资源。
+默认只删除创建日期超过2天的用户，可通过 --days-old 参数调整。
+设置 --days-old=0 将删除所有匹配的用户（不考虑创建时间）。
+
+示例:
+  gitlab-cli user cleanup -f config.yaml                    # 只删除2天前创建的用户
+  gitlab-cli user cleanup -f config.yaml --days-old 7       # 只删除7天前创建的用户
+  gitlab-cli user cleanup -f config.yaml --days-old 0       # 删除所有用户（不检查创建时间）
+  gitlab-cli user cleanup -f config.yaml --run-id 20251030150000123-a1b2  # 清理指定运行创建的资源`: `Clean up the users defined in a config file and all of their resources.
+By default only users created more than 2 days ago are deleted; adjust this with --days-old.
+--days-old=0 deletes every matching user regardless of creation time.
+
+Examples:
+  gitlab-cli user cleanup -f config.yaml                    # delete users created more than 2 days ago
+  gitlab-cli user cleanup -f config.yaml --days-old 7       # delete users created more than 7 days ago
+  gitlab-cli user cleanup -f config.yaml --days-old 0       # delete all users (no creation time check)
+  gitlab-cli user cleanup -f config.yaml --run-id 20251030150000123-a1b2  # clean up the resources of one run`,
+	"只删除创建日期超过指定天数的用户（0表示删除所有用户）":              "Only delete users created more than this many days ago (0 deletes all users)",
+	"根据运行 ID 推导 prefix 模式生成的名称，使原始配置文件可直接用于清理": "Derive the names generated in prefix mode from a run ID so the original config file can be used for cleanup",
+	"删除指定用户名的用户及其项目和组":                         "Delete users by username together with their projects and groups",
+	`根据用户名删除用户及其所有资源（项目和组）。
+支持删除多个用户，用户名之间用逗号分隔。
+
+示例:
+  gitlab-cli user delete --username user1
+  gitlab-cli user delete --username user1,user2,user3`: `Delete users by username together with all of their resources (projects and groups).
+Several users can be deleted at once, separated by commas.
+
+Examples:
+  gitlab-cli user delete --username user1
+  gitlab-cli user delete --username user1,user2,user3`,
+	"要删除的用户名（多个用户用逗号分隔）": "Usernames to delete (comma separated)",
+	"找到用户配置":  "found user configs",
+	"使用自定义后缀": "using custom suffix",
+	"运行 ID":   "run ID",
+	"处理用户":    "processing user",
+	"用户已在上次运行中完成，跳过":                             "user finished in a previous run, skipping",
+	"上次运行已完成":                                    "finished in a previous run",
+	"处理用户时出错":                                    "failed to process user",
+	"用户处理完成":                                     "user processed",
+	"创建中断，可使用 --resume 继续":                       "create interrupted, continue with --resume",
+	"批量创建完成":                                     "batch create finished",
+	"部分资源创建失败，可使用 --resume 重试":                   "some resources failed to create, retry with --resume",
+	"保存结果":                                       "saving results",
+	"--run-id %[1]s 与 %[3]s 中记录的运行 ID %[2]s 不一致": "--run-id %[1]s does not match run ID %[2]s recorded in %[3]s",
+	"无效的 --run-id %q：只能包含字母、数字、'_' 和 '-'":        "invalid --run-id %q: only letters, digits, '_' and '-' are allowed",
+	"断点文件记录的配置文件与当前不同":                           "checkpoint file was written for a different config file",
+	"从断点文件恢复":                                    "resuming from checkpoint file",
+	"断点文件":                                       "checkpoint file",
+	"只删除创建日期超过指定天数的用户":                           "only deleting users created more than the given number of days ago",
+	"将删除所有匹配的用户（不检查创建时间）":                        "deleting all matching users (creation time not checked)",
+	"清理运行创建的资源":                                  "cleaning up resources created by run",
+	"批量清理完成":                                     "batch cleanup finished",
+	"准备删除用户":                                     "deleting users",
+	"删除用户时出错":                                    "failed to delete user",
+	"批量删除完成":                                     "batch delete finished",
+	"列出所有用户或搜索特定前缀的用户":                           "List all users or search users by prefix",
+	`列出 GitLab 上的用户。
+可以使用 --prefix 参数搜索特定前缀的用户。
+
+示例:
+  gitlab-cli user list
+  gitlab-cli user list --prefix tektoncd`: `List the users on GitLab.
+Use --prefix to search for users with a given prefix.
+
+Examples:
+  gitlab-cli user list
+  gitlab-cli user list --prefix tektoncd`,
+	"搜索用户名前缀":         "Username prefix to search for",
+	"删除所有匹配前缀的用户及其资源": "Delete all users matching a prefix together with their resources",
+	`根据用户名前缀批量删除用户及其所有资源（项目和组）。
+指定 --run-id 时只删除该次运行创建的用户，此时 --prefix 可省略。
+支持 --dry-run 模式预览将要删除的用户。
+默认只删除创建日期超过2天的用户，可通过 --days-old 参数调整。
+
+示例:
+  gitlab-cli user delete-by-prefix --prefix tektoncd --dry-run            # 预览2天前创建的用户
+  gitlab-cli user delete-by-prefix --prefix tektoncd                      # 删除2天前创建的用户
+  gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 7         # 删除7天前创建的用户
+  gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 0         # 删除所有匹配前缀的用户
+  gitlab-cli user delete-by-prefix --run-id 20251030150000123-a1b2 --days-old 0  # 删除指定运行创建的所有用户`: `Delete users by username prefix together with all of their resources (projects and groups).
+With --run-id only the users created by that run are deleted and --prefix may be omitted.
+Use --dry-run to preview the users that would be deleted.
+By default only users created more than 2 days ago are deleted; adjust this with --days-old.
+
+Examples:
+  gitlab-cli user delete-by-prefix --prefix tektoncd --dry-run            # preview users created more than 2 days ago
+  gitlab-cli user delete-by-prefix --prefix tektoncd                      # delete users created more than 2 days ago
+  gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 7         # delete users created more than 7 days ago
+  gitlab-cli user delete-by-prefix --prefix tektoncd --days-old 0         # delete all users matching the prefix
+  gitlab-cli user delete-by-prefix --run-id 20251030150000123-a1b2 --days-old 0  # delete all users created by one run`,
+	"必须指定 --prefix 或 --run-id":              "--prefix or --run-id is required",
+	"要删除的用户名前缀":                             "Username prefix to delete",
+	"只删除该运行 ID 创建的用户":                       "Only delete users created by this run ID",
+	"只显示将要删除的用户，不实际删除":                      "Only show the users that would be deleted without deleting them",
+	"正在获取用户列表":                              "listing users",
+	"找到的用户数":                                "users found",
+	"正在搜索运行创建的用户":                           "searching users created by run",
+	"正在搜索用户名以前缀开头的用户":                       "searching users whose username starts with prefix",
+	"未找到运行创建的用户":                            "no users created by run found",
+	"未找到以前缀开头的用户":                           "no users with prefix found",
+	"按创建日期过滤":                               "filtering by creation date",
+	"跳过用户：无法获取创建时间":                         "skipping user: creation time unknown",
+	"跳过用户：创建时间未超过指定天数":                      "skipping user: created too recently",
+	"没有符合条件的用户需要删除":                         "no matching users to delete",
+	"找到符合条件的用户":                             "found matching users",
+	"%s (%d 天前)":                            "%s (%d days ago)",
+	"[DRY-RUN] 以上用户将被删除（当前为预览模式）":           "[DRY-RUN] the users above would be deleted (preview mode)",
+	"准备删除用户及其所有资源":                          "deleting users and all of their resources",
+	"检查 GitLab 连接和权限":                       "checking GitLab connection and permissions",
+	"操作已取消，部分用户未处理完成":                       "operation canceled, some users were not processed",
+	"API 重试次数":                              "API retries",
+	"瞬时 API 错误（5xx、429、409）的最大尝试次数，1 表示不重试": "Maximum attempts for transient API errors (5xx, 429, 409); 1 disables retries",
+	"第一次重试前的退避时间，之后每次翻倍并带随机抖动":              "Backoff before the first retry, doubled with random jitter after each attempt",
+	"重试退避时间的上限（服务端返回的 Retry-After 优先）":      "Upper limit of the retry backoff (Retry-After from the server takes precedence)",
+	"配置和输出文件的格式: yaml、json 或 toml（默认根据扩展名判断，- 和其他扩展名按 yaml）": "Format of config and output files: yaml, json or toml (default: by extension, yaml for - and other extensions)",
+	"使用 age 加密输出文件：age1 开头的公钥，可重复指定；passphrase 表示使用 %s 中的口令": "Encrypt output files with age: an age1 public key, repeatable; passphrase uses the passphrase in %s",
+	"每次等待 GitLab 完成异步删除的最长时间":                                "Maximum time to wait for each asynchronous deletion in GitLab",
+	"并发处理的用户数": "Number of users processed concurrently",
+	"所有并发任务共享的每秒最大 API 请求数（0 表示不限制）": "Maximum API requests per second shared by all workers (0 means unlimited)",
+
+	// internal/cli/events.go
+	"以 NDJSON 格式追加写入每个资源操作事件的文件（- 表示标准输出）":     "File to append an NDJSON event for every resource operation to (- for standard output)",
+	"--events-file - 和 --output - 不能同时输出到标准输出": "--events-file - and --output - cannot both write to standard output",
+
+	// internal/cli/exit.go
+	"结果汇总":          "summary",
+	"%d 个资源操作失败":    "%d resource operations failed",
+	"全部 %d 个资源操作失败": "all %d resource operations failed",
+
+	// internal/cli/keygen.go
+	"生成加密输出文件使用的 age 密钥对": "Generate an age key pair for encrypting output files",
+	`生成 X25519 密钥对，与 age-keygen 兼容。
+私钥写入 --output 指定的文件（权限 0600），公钥打印到标准错误，用于 --encrypt-to。
+
+示例:
+  gitlab-cli keygen -o key.txt
+  gitlab-cli user create -f config.yaml -o output.yaml --encrypt-to age1...
+  gitlab-cli render --identity key.txt --data output.yaml -t template.yaml`: `Generate an X25519 key pair compatible with age-keygen.
+The private key is written to the --output file (mode 0600) and the public key is printed to standard error for use with --encrypt-to.
+
+Examples:
+  gitlab-cli keygen -o key.txt
+  gitlab-cli user create -f config.yaml -o output.yaml --encrypt-to age1...
+  gitlab-cli render --identity key.txt --data output.yaml -t template.yaml`,
+	"私钥文件（- 表示标准输出）": "Private key file (- for standard output)",
+	"生成密钥失败: %w":     "generate key: %w",
+	"写入私钥文件失败: %w":   "write key file: %w",
+	"公钥: %s\n":       "Public key: %s\n",
+
+	// internal/cli/metrics.go
+	"运行结束后以 Prometheus textfile 格式写入指标的文件（供 node_exporter textfile collector 收集）": "File to write metrics to in the Prometheus textfile format when the run ends (for the node_exporter textfile collector)",
+	"写入指标失败":   "failed to write metrics",
+	"指标已写入":    "metrics written",
+	"API 调用统计": "API call totals",
+
+	// internal/cli/output.go
+	"--encrypt-fields 需要同时指定 --encrypt-to":          "--encrypt-fields requires --encrypt-to",
+	"--output-format 需要一个未指定模板的 --output（- 表示标准输出）": "--output-format requires an --output without a template (use - for stdout)",
+	"--template 需要一个未指定模板的 --output（- 表示标准输出）":      "--template requires an --output without a template (use - for stdout)",
+	"输出 %s 被重复指定":                                   "output %s is specified more than once",
+	"输出 %s: %w":                                     "output %s: %w",
+	"输出 %s: 内置格式 %s 不支持 redact":                     "output %s: redact cannot be used with the built-in format %s",
+	"输出 %s: 模板 %w":                                  "output %s: template %w",
+	"同一主机有多个用户，客户端只会使用文件中的第一条凭证":                    "several users share one host; clients only use the first credential in the file",
+	"加密失败: %w":                                      "encrypt: %w",
+	"写入输出文件 %s 失败: %w":                              "write output file %s: %w",
+	"结果已保存":                                         "results saved",
+
+	// internal/cli/render.go
+	"使用已保存的输出结果离线渲染模板": "Render templates offline from a saved output file",
+	`读取 user create -o 保存的输出结果，使用模板渲染，不连接 GitLab。
+修改模板后无需重新创建用户，也可以从一次创建生成多种格式的文件。
+
+可以重复 -t 和 -o 渲染多个模板，第 N 个模板写到第 N 个输出文件；
+只有一个模板且不指定 -o 时输出到标准输出。
+
+示例:
+  gitlab-cli render --data output.yaml -t template.yaml
+  gitlab-cli render --data output.yaml -t template.yaml -o result.yaml
+  gitlab-cli render --data output.json -t ci.tpl -o ci.env -t secret.tpl -o secret.yaml
+  gitlab-cli render --identity key.txt --data output.yaml.age -t secret.tpl -o secret.yaml`: `Read the output saved by user create -o and render templates with it, without connecting to GitLab.
+Templates can be changed without recreating users, and one create can produce files in several formats.
+
+Repeat -t and -o to render several templates; the Nth template is written to the Nth output file.
+With a single template and no -o the result is printed to standard output.
+
+Examples:
+  gitlab-cli render --data output.yaml -t template.yaml
+  gitlab-cli render --data output.yaml -t template.yaml -o result.yaml
+  gitlab-cli render --data output.json -t ci.tpl -o ci.env -t secret.tpl -o secret.yaml
+  gitlab-cli render --identity key.txt --data output.yaml.age -t secret.tpl -o secret.yaml`,
+	"user create 保存的输出文件（- 表示标准输入）":                      "Output file saved by user create (- for standard input)",
+	"模板文件，可重复指定":                                         "Template file, repeatable",
+	"渲染结果的输出文件，与 --template 按顺序对应（- 表示标准输出）":             "Output file for the rendered result, matched to --template by order (- for standard output)",
+	"输出结果文件的格式: yaml、json 或 toml（默认根据扩展名判断）":             "Format of the output file: yaml, json or toml (default: by extension)",
+	"渲染前把密码和 Token 替换为 ${<USERNAME>_TOKEN} 形式的引用":        "Replace passwords and tokens with references like ${<USERNAME>_TOKEN} before rendering",
+	"指定了 %d 个模板和 %d 个输出文件：每个 --template 都需要对应的 --output": "got %d templates and %d outputs: every --template needs its own --output",
+	"读取输出结果":       "read output file",
+	"渲染 %s 失败: %w": "render %s: %w",
+	"加密 %s 失败: %w": "encrypt %s: %w",
+	"模板已渲染":        "template rendered",
+
+	// internal/cli/report.go
+	"运行结束后写入报告，可重复指定；按扩展名输出 JUnit XML（.xml）或 Markdown 表格（.md）": "Write a run report when the run finishes, repeatable; JUnit XML (.xml) or a Markdown table (.md) by extension",
+	"不支持的报告格式 %q，报告文件的扩展名必须是 .xml 或 .md":                       "unsupported report format %q, the report file extension must be .xml or .md",
+	"写入报告失败": "failed to write report",
+	"报告已写入":  "report written",
+
+	// internal/cli/trace.go
+	"运行结束后以 OTLP/JSON 格式写入 trace 的文件（- 表示标准输出）":    "File to write the run's trace to as OTLP/JSON when it ends (- for stdout)",
+	"运行结束后列出耗时最长的操作":                               "List the slowest operations when the run ends",
+	"--trace-file - 和 --events-file - 不能同时输出到标准输出": "--trace-file - and --events-file - cannot both write to stdout",
+	"--trace-file - 和 --output - 不能同时输出到标准输出":      "--trace-file - and --output - cannot both write to stdout",
+	"写入 trace 失败": "failed to write trace",
+	"trace 已写入":   "trace written",
+	"耗时最长的操作":     "slowest operation",
+	"耗时最长的操作:":    "Slowest operations:",
+
+	// internal/cli/verify.go
+	"检查输出结果中记录的资源是否仍与 GitLab 一致": "Check that the resources recorded in an output file still match GitLab",
+	`读取 user create -o 保存的输出结果，逐个检查其中记录的资源是否仍与 GitLab 一致：
+  - 用户存在且处于 active 状态
+  - 组和项目存在，ID 和可见性与记录相同
+  - Token 能够认证（通过 /personal_access_tokens/self），属于该用户并带有记录的权限范围
+不修改任何资源。与记录不一致（漂移）的资源在汇总中记为失败并列出差异，退出码与其他命令相同。
+使用 --redact 保存的输出结果中 Token 是 ${<USERNAME>_TOKEN} 形式的引用，从环境变量读取。
+
+示例:
+  gitlab-cli verify --from-output output.yaml
+  gitlab-cli verify --from-output output.json --report verify.xml
+  gitlab-cli verify --identity key.txt --from-output output.yaml.age`: `Read an output file saved by user create -o and check that every recorded resource still matches GitLab:
+  - the user exists and is active
+  - groups and projects exist with the recorded IDs and visibility
+  - the token authenticates (via /personal_access_tokens/self), belongs to the user and carries the recorded scopes
+Nothing is modified. Resources that drifted from the record are reported as failed in the summary with
+the differences, and the exit code is the same as for the other commands.
+In output files saved with --redact the token is a reference like ${<USERNAME>_TOKEN} and is read from the environment.
+
+Examples:
+  gitlab-cli verify --from-output output.yaml
+  gitlab-cli verify --from-output output.json --report verify.xml
+  gitlab-cli verify --identity key.txt --from-output output.yaml.age`,
+	"输出结果中没有用户": "the output file contains no users",
+	"校验完成":      "verification finished",
+
+	// internal/events/events.go
+	"写入事件失败": "failed to write event",
+
+	// internal/processor/processor.go
+	"命名策略":                     "naming strategy",
+	"生成用户名":                    "generated username",
+	"用户创建失败":                   "user creation failed",
+	"已存在":                      "already exists",
+	"复用断点文件中的 Token":           "reused token from checkpoint file",
+	"创建 Personal Access Token": "creating Personal Access Token",
+	"创建 Token 失败":              "failed to create token",
+	"Token 创建成功":               "token created",
+	"创建组":                      "creating group",
+	"创建用户级项目":                  "creating user project",
+	"创建用户级项目失败":                "failed to create user project",
+	"未指定 Token 过期时间，使用默认值（第2天）": "no token expiry configured, using the default (tomorrow)",
+	"检查用户失败":                   "failed to check user",
+	"用户已存在":                    "user already exists",
+	"创建用户":                     "creating user",
+	"用户创建成功":                   "user created",
+	"处理组":                      "processing group",
+	"创建组失败":                    "failed to create group",
+	"所属组创建失败":                  "parent group creation failed",
+	"创建组内项目":                   "creating group project",
+	"创建组内项目失败":                 "failed to create group project",
+	"生成组 path":                 "generated group path",
+	"检查组失败: %w":                "check group: %w",
+	"组已存在":                     "group already exists",
+	"组创建成功":                    "group created",
+	"获取用户 namespace ID 失败: %w": "get user namespace ID: %w",
+	"获取用户 namespace":           "got user namespace",
+	"生成项目 path 失败":             "failed to generate project path",
+	"生成项目 path":                "generated project path",
+	"检查项目失败":                   "failed to check project",
+	"检查项目失败: %w":               "check project: %w",
+	"项目已存在":                    "project already exists",
+	"创建项目":                     "creating project",
+	"创建项目失败":                   "failed to create project",
+	"项目创建成功":                   "project created",
+	"推导出创建时生成的用户名":             "derived the username generated at create time",
+	"检查用户失败: %w":               "check user: %w",
+	"用户不存在，跳过":                 "user does not exist, skipping",
+	"不存在":                      "does not exist",
+	"找到用户":                     "found user",
+	"无法获取用户创建时间，跳过删除":          "user creation time unknown, skipping deletion",
+	"无法获取创建时间":                 "creation time unknown",
+	"用户创建时间":                   "user creation time",
+	"用户创建时间未超过指定天数，跳过删除":  "user created too recently, skipping deletion",
+	"创建未超过 %d 天":          "created less than %d days ago",
+	"用户创建时间已超过指定天数，将进行删除": "user is old enough, deleting",
+	"删除用户级项目":             "deleting user projects",
+	"删除组及其项目":             "deleting groups and their projects",
+	"部分组可能仍然存在":           "some groups may still exist",
+	"删除用户失败":              "failed to delete user",
+	"获取用户项目列表失败":          "failed to list user projects",
+	"获取用户项目列表失败: %w":      "list user projects: %w",
+	"用户没有个人项目":            "user has no personal projects",
+	"删除用户的个人项目":           "deleting personal projects of user",
+	"删除项目":                "deleting project",
+	"删除项目失败":              "failed to delete project",
+	"项目删除成功":              "project deleted",
+	"删除组内项目":              "deleting group project",
+	"检查组失败":               "failed to check group",
+	"删除组":                 "deleting group",
+	"删除组失败":               "failed to delete group",
+	"组删除成功":               "group deleted",
+	"等待 GitLab 处理组删除":     "waiting for GitLab to process group deletion",
+	"验证组删除状态":             "verifying group deletion",
+	"组仍然存在":               "group still exists",
+	"组未完全删除，继续等待":         "group not fully deleted yet, waiting",
+	"验证通过: 配置文件中的组已彻底删除":  "verified: groups from the config file are fully deleted",
+	"检查用户是否还拥有其他组":        "checking whether the user owns other groups",
+	"获取用户组列表失败":           "failed to list user groups",
+	"获取用户组列表失败: %w":       "list user groups: %w",
+	"用户没有拥有其他组":           "user owns no other groups",
+	"删除用户拥有的其他组":          "deleting other groups owned by user",
+	"等待用户所有组删除完成":         "waiting for all groups of the user to be deleted",
+	"用户的部分组可能仍然存在":        "some groups of the user may still exist",
+	"验证通过: 用户所有组已彻底删除":    "verified: all groups of the user are fully deleted",
+	"等待 GitLab 内部数据同步":    "waiting for GitLab to sync internal data",
+	"等待数据同步超时，继续删除用户":     "timed out waiting for data sync, deleting user anyway",
+	"删除用户":                "deleting user",
+	"用户删除成功":              "user deleted",
+	"等待 GitLab 完成删除操作":    "waiting for GitLab to finish deletion",
+	"验证通过: 用户已彻底删除":       "verified: user is fully deleted",
+	"验证失败: 用户可能仍然存在":      "verification failed: user may still exist",
+
+	// internal/processor/verify.go
+	"用户检查失败":            "user check failed",
+	"用户不存在":             "user does not exist",
+	"输出结果中没有 Token 值":   "no token value in the output file",
+	"资源与记录一致":           "resource matches the record",
+	"资源与记录不一致":          "resource drifted from the record",
+	"检查失败: %v":          "check failed: %v",
+	"ID 为 %d，记录为 %d":    "ID is %d, recorded %d",
+	"状态为 %s":            "state is %s",
+	"无法认证（无效、已撤销或已过期）":  "cannot authenticate (invalid, revoked or expired)",
+	"已失效":               "inactive",
+	"属于用户 ID %d，应为 %d":  "belongs to user ID %d, want %d",
+	"缺少权限范围 %s（实际为 %s）": "missing scopes %s (has %s)",
+	"过期时间为 %s，记录为 %s":   "expires at %s, recorded %s",
+	"可见性为 %s，记录为 %s":    "visibility is %s, recorded %s",
+
+	// pkg/client/client.go
+	"认证成功（管理员权限）":          "authenticated (admin)",
+	"未找到用户 %s 的 namespace": "namespace of user %s not found",
+	"获取用户信息失败: %w":         "get user: %w",
+	"解除封锁用户失败":             "failed to unblock user",
+	"批准用户失败":               "failed to approve user",
+
+	// pkg/client/retry.go
+	"API 调用失败，稍后重试": "API call failed, retrying",
+}
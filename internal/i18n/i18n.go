@@ -0,0 +1,91 @@
+// Package i18n 提供命令帮助和运行时消息的中英文翻译。
+//
+// 代码中的中文原文即消息 ID：T 在中文环境下原样返回，在英文环境下从 catalog_en.go 的目录中查找译文。
+// 语言由 --lang 指定，未指定时依次参考 LC_ALL、LC_MESSAGES 和 LANG 环境变量。
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// 支持的语言
+const (
+	ZH = "zh"
+	EN = "en"
+)
+
+// lang 是当前语言，默认为中文
+var lang atomic.Value
+
+func init() {
+	lang.Store(ZH)
+}
+
+// Detect 返回本次运行使用的语言。命令帮助在解析参数前就已生成，因此这里直接从 args 中
+// 查找 --lang；未指定时根据 locale 环境变量判断，未设置或为 C/POSIX 时使用中文
+func Detect(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--lang="); ok {
+			return value
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			return fromLocale(value)
+		}
+	}
+	return ZH
+}
+
+// fromLocale 把 zh_CN.UTF-8、en_US 等 locale 转换为支持的语言
+func fromLocale(locale string) string {
+	switch {
+	case locale == "C" || locale == "POSIX" || strings.HasPrefix(locale, "C."):
+		return ZH
+	case strings.HasPrefix(strings.ToLower(locale), ZH):
+		return ZH
+	default:
+		return EN
+	}
+}
+
+// SetLang 设置当前语言，只接受 zh 和 en
+func SetLang(value string) error {
+	switch value {
+	case ZH, EN:
+		lang.Store(value)
+		return nil
+	default:
+		return fmt.Errorf("unsupported language %q (want %s or %s)", value, ZH, EN)
+	}
+}
+
+// Lang 返回当前语言
+func Lang() string {
+	return lang.Load().(string)
+}
+
+// T 返回 msg 在当前语言下的文本，英文目录中缺少的消息原样返回
+func T(msg string) string {
+	if Lang() == ZH {
+		return msg
+	}
+	if translated, ok := en[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Tf 翻译格式串 format 后按 fmt.Sprintf 格式化
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{name: "default", want: ZH},
+		{name: "C locale", env: map[string]string{"LANG": "C.UTF-8"}, want: ZH},
+		{name: "zh locale", env: map[string]string{"LANG": "zh_CN.UTF-8"}, want: ZH},
+		{name: "en locale", env: map[string]string{"LANG": "en_US.UTF-8"}, want: EN},
+		{name: "LC_ALL wins", env: map[string]string{"LC_ALL": "zh_TW", "LANG": "en_US"}, want: ZH},
+		{name: "flag", args: []string{"user", "cleanup", "--lang", "en"}, env: map[string]string{"LANG": "zh_CN"}, want: EN},
+		{name: "flag with value", args: []string{"--lang=zh", "user"}, env: map[string]string{"LANG": "en_US"}, want: ZH},
+		{name: "after --", args: []string{"--", "--lang", "en"}, want: ZH},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(env, tt.env[env])
+			}
+			if got := Detect(tt.args); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { _ = SetLang(ZH) })
+
+	if err := SetLang("fr"); err == nil {
+		t.Errorf("SetLang(fr) error = nil, want an error")
+	}
+	if got := T("用户创建成功"); got != "用户创建成功" {
+		t.Errorf("T() in zh = %q", got)
+	}
+	if err := SetLang(EN); err != nil {
+		t.Fatalf("SetLang(en) error = %v", err)
+	}
+	if got := T("用户创建成功"); got != "user created" {
+		t.Errorf("T() in en = %q", got)
+	}
+	if got := Tf("创建未超过 %d 天", 2); got != "created less than 2 days ago" {
+		t.Errorf("Tf() in en = %q", got)
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// TestCatalogComplete 检查代码中所有 T 和 Tf 的消息都有英文译文，且译文与原文使用相同的格式化动词
+func TestCatalogComplete(t *testing.T) {
+	used := map[string]bool{}
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "T" && sel.Sel.Name != "Tf") {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				t.Errorf("%s: i18n.%s must be called with a string literal", path, sel.Sel.Name)
+				return true
+			}
+			msg, _ := strconv.Unquote(lit.Value)
+			used[msg] = true
+			translated, ok := en[msg]
+			if !ok {
+				t.Errorf("%s: missing English translation for %q", path, msg)
+				return true
+			}
+			if got, want := verbs(translated), verbs(msg); got != want {
+				t.Errorf("%s: translation of %q uses verbs %s, want %s", path, msg, got, want)
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk sources: %v", err)
+	}
+	for msg := range en {
+		if !used[msg] {
+			t.Errorf("unused English translation for %q", msg)
+		}
+	}
+}
+
+// verbs 返回格式串中去掉参数下标后的格式化动词，排序后比较，允许译文调整参数顺序
+func verbs(format string) string {
+	found := verbPattern.FindAllStringSubmatch(format, -1)
+	result := make([]string, len(found))
+	for i, match := range found {
+		result[i] = strings.Replace(match[0], match[1], "", 1)
+	}
+	slices.Sort(result)
+	return strings.Join(result, " ")
+}
@@ -0,0 +1,159 @@
+// Package logging 配置基于 log/slog 的结构化日志。
+//
+// 日志支持 text 和 json 两种格式，可写到标准错误或文件；每行日志通过 KeyUser、KeyGroup 等
+// 公共字段标明所属资源，供日志系统按字段检索。除非指定 --show-secrets，已登记的凭证会被隐藏。
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"gitlab-cli-sdk/internal/redact"
+)
+
+// 日志格式
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// 日志的公共字段名
+const (
+	KeyUser     = "user"     // 配置文件中的用户名
+	KeyGroup    = "group"    // 组 path
+	KeyProject  = "project"  // 项目 full path
+	KeyAction   = "action"   // create、delete 等操作
+	KeyDuration = "duration" // 操作耗时，json 格式中以秒为单位
+	KeyError    = "error"
+)
+
+// Options 是日志相关的命令行参数
+type Options struct {
+	Level       string // debug、info、warn 或 error
+	Format      string // text 或 json
+	File        string // 日志文件，为空时写到标准错误
+	ShowSecrets bool   // 不隐藏已登记的凭证
+}
+
+// console 是面向用户的汇总表等非日志内容的输出，隐藏凭证的设置与日志相同
+var console io.Writer = os.Stderr
+
+// format 是当前的日志格式
+var format = FormatText
+
+// redacting 表示是否在日志中隐藏已登记的凭证
+var redacting bool
+
+// Setup 按 opts 配置默认的 slog 日志器，标准 log 包的输出也会转为结构化日志。
+// 日志文件在进程退出前一直保持打开
+func Setup(opts Options) error {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+	if opts.Format == "" {
+		opts.Format = FormatText
+	}
+	if opts.Format != FormatText && opts.Format != FormatJSON {
+		return fmt.Errorf("unsupported log format %q (want %s or %s)", opts.Format, FormatText, FormatJSON)
+	}
+
+	var w io.Writer = os.Stderr
+	if opts.File != "" {
+		file, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("open log file: %w", err)
+		}
+		w = file
+	}
+	console = os.Stderr
+	// 凭证在 replaceAttr 中按原始值隐藏；写出时再替换一次，覆盖 slog 以外直接写到 w 的内容
+	redacting = !opts.ShowSecrets
+	if redacting {
+		w = redact.NewWriter(w)
+		console = redact.NewWriter(console)
+	}
+	format = opts.Format
+
+	handlerOpts := &slog.HandlerOptions{Level: level, ReplaceAttr: replaceAttr}
+	var handler slog.Handler = slog.NewTextHandler(w, handlerOpts)
+	if opts.Format == FormatJSON {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	}
+	slog.SetDefault(slog.New(handler))
+	// SetDefault 会把标准 log 包转到 slog，去掉 log 自带的时间前缀
+	log.SetFlags(0)
+	return nil
+}
+
+// ParseLevel 解析日志级别，空字符串表示 info
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(strings.ToUpper(s))); err != nil {
+		return 0, fmt.Errorf("unsupported log level %q (want debug, info, warn or error)", s)
+	}
+	return level, nil
+}
+
+// replaceAttr masks registered secrets, rounds durations to milliseconds, and writes them as
+// seconds in JSON where slog would otherwise use nanoseconds.
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if redacting {
+		a.Value = redactValue(a.Value)
+	}
+	if a.Value.Kind() != slog.KindDuration {
+		return a
+	}
+	d := a.Value.Duration().Round(time.Millisecond)
+	if format == FormatJSON {
+		return slog.Float64(a.Key, d.Seconds())
+	}
+	return slog.Duration(a.Key, d)
+}
+
+// redactValue masks registered secrets in strings, errors and Stringers before the handler
+// quotes and escapes them; a secret containing " or \ no longer matches once escaped.
+func redactValue(v slog.Value) slog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.StringValue(redact.String(v.String()))
+	case slog.KindAny:
+		switch x := v.Any().(type) {
+		case error:
+			return slog.StringValue(redact.String(x.Error()))
+		case fmt.Stringer:
+			if s := x.String(); redact.String(s) != s {
+				return slog.StringValue(redact.String(s))
+			}
+		}
+	}
+	return v
+}
+
+// IsJSON 判断日志是否为 json 格式，此时不输出面向人的表格
+func IsJSON() bool {
+	return format == FormatJSON
+}
+
+// Console 返回输出汇总表等面向用户内容的 io.Writer
+func Console() io.Writer {
+	return console
+}
+
+// Duration 返回从 started 到现在的耗时字段
+func Duration(started time.Time) slog.Attr {
+	return slog.Duration(KeyDuration, time.Since(started))
+}
+
+// Err 返回错误字段
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
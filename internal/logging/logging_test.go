@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab-cli-sdk/internal/redact"
+)
+
+func TestSetupJSONFile(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(defaultLogger)
+		format = FormatText
+	})
+
+	file := filepath.Join(t.TempDir(), "run.log")
+	if err := Setup(Options{Level: "warn", Format: FormatJSON, File: file}); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	redact.Add("glpat-logging-test")
+
+	slog.Info("filtered by level")
+	slog.With(KeyUser, "bot", KeyAction, "create").Warn("slow", KeyProject, "bot/demo",
+		slog.Duration(KeyDuration, 1500*time.Millisecond), "token", "glpat-logging-test")
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("log file has %d lines, want 1:\n%s", len(lines), data)
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("log line is not JSON: %v\n%s", err, lines[0])
+	}
+	want := map[string]any{"msg": "slow", "user": "bot", "action": "create", "project": "bot/demo", "duration": 1.5, "token": redact.Mask}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+}
+
+// TestSetupRedactsEscapedSecrets verifies that secrets are masked before slog escapes them, so
+// that quotes and backslashes in a password do not leak it.
+func TestSetupRedactsEscapedSecrets(t *testing.T) {
+	defaultLogger := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(defaultLogger)
+		format = FormatText
+	})
+	const secret = `pa"ss\word-logging`
+	redact.Add(secret)
+
+	for _, logFormat := range []string{FormatText, FormatJSON} {
+		t.Run(logFormat, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "run.log")
+			if err := Setup(Options{Format: logFormat, File: file}); err != nil {
+				t.Fatalf("Setup() error = %v", err)
+			}
+			slog.Warn("login "+secret, "password", secret, Err(errors.New("rejected "+secret)))
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("read log file: %v", err)
+			}
+			if strings.Contains(string(data), "word-logging") || strings.Count(string(data), redact.Mask) != 3 {
+				t.Errorf("secret is not masked:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	for _, opts := range []Options{{Level: "verbose"}, {Format: "xml"}} {
+		if err := Setup(opts); err == nil {
+			t.Errorf("Setup(%+v) error = nil, want an error", opts)
+		}
+	}
+}
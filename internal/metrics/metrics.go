@@ -0,0 +1,321 @@
+// Package metrics 统计一次运行中的 API 调用、重试、资源操作和等待 GitLab 完成删除的时间，
+// 运行结束后以 Prometheus textfile 格式导出，供 node_exporter 的 textfile collector 收集。
+//
+// Registry 通过 context 传递：NewContext 把 Registry 放入 context 后，客户端和处理器从
+// context 中取出并记录；context 中没有 Registry 时 FromContext 返回 nil，所有方法都是空操作。
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab-cli-sdk/internal/result"
+)
+
+// Prefix 是所有指标名称的前缀
+const Prefix = "gitlab_cli_"
+
+var (
+	// apiBuckets 是单次 API 请求耗时的直方图上界（秒）
+	apiBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	// resourceBuckets 是单个资源操作（包括其中的重试）耗时的直方图上界（秒）
+	resourceBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+	// waitBuckets 是等待 GitLab 完成删除的耗时直方图上界（秒）
+	waitBuckets = []float64{1, 5, 10, 30, 60, 120, 300, 600}
+)
+
+// Registry 收集一次运行的指标，可在多个 goroutine 中并发使用；nil *Registry 丢弃所有指标
+type Registry struct {
+	mu           sync.Mutex
+	apiCalls     map[apiKey]int
+	apiDurations map[string]*histogram
+	retries      map[string]int
+	resources    map[resourceKey]int
+	durations    map[resourceKey]*histogram
+	waits        map[string]*histogram
+	waitTimeouts map[string]int
+	run          *runInfo
+}
+
+type apiKey struct {
+	endpoint string
+	status   string
+}
+
+type resourceKey struct {
+	kind   result.Kind
+	action result.Action
+	status result.Status
+}
+
+type runInfo struct {
+	action   string
+	finished time.Time
+	duration time.Duration
+	success  bool
+}
+
+// New 创建空的 Registry
+func New() *Registry {
+	return &Registry{
+		apiCalls:     make(map[apiKey]int),
+		apiDurations: make(map[string]*histogram),
+		retries:      make(map[string]int),
+		resources:    make(map[resourceKey]int),
+		durations:    make(map[resourceKey]*histogram),
+		waits:        make(map[string]*histogram),
+		waitTimeouts: make(map[string]int),
+	}
+}
+
+type contextKey struct{}
+
+// NewContext 返回带有 Registry 的 context
+func NewContext(ctx context.Context, r *Registry) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext 返回 context 中的 Registry，没有时返回 nil
+func FromContext(ctx context.Context) *Registry {
+	r, _ := ctx.Value(contextKey{}).(*Registry)
+	return r
+}
+
+// ObserveAPICall 记录一次 HTTP 请求。endpoint 是客户端方法名，例如 CreateProject；
+// status 是 HTTP 状态码，没有收到响应时为 0，记为 "error"
+func (r *Registry) ObserveAPICall(endpoint string, status int, d time.Duration) {
+	if r == nil {
+		return
+	}
+	label := "error"
+	if status != 0 {
+		label = strconv.Itoa(status)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apiCalls[apiKey{endpoint, label}]++
+	observe(r.apiDurations, endpoint, apiBuckets, d)
+}
+
+// ObserveRetry 记录一次重试
+func (r *Registry) ObserveRetry(endpoint string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries[endpoint]++
+}
+
+// ObserveResource 记录一个资源操作的耗时。资源操作的次数在运行结束时由 ObserveSummary 统计
+func (r *Registry) ObserveResource(kind result.Kind, action result.Action, status result.Status, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	observe(r.durations, resourceKey{kind, action, status}, resourceBuckets, d)
+}
+
+// ObserveWait 记录一次等待 GitLab 完成异步删除的耗时，timedOut 表示等待超时
+func (r *Registry) ObserveWait(kind result.Kind, d time.Duration, timedOut bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	observe(r.waits, string(kind), waitBuckets, d)
+	if timedOut {
+		r.waitTimeouts[string(kind)]++
+	}
+}
+
+// ObserveSummary 按资源类型、操作和结果统计资源操作
+func (r *Registry) ObserveSummary(summary *result.Summary) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range summary.Entries {
+		r.resources[resourceKey{entry.Kind, entry.Action, entry.Status}]++
+	}
+}
+
+// ObserveRun 记录运行的操作、耗时和是否成功
+func (r *Registry) ObserveRun(action result.Action, d time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run = &runInfo{action: string(action), finished: time.Now(), duration: d, success: err == nil}
+}
+
+// Totals 是最终汇总中显示的统计
+type Totals struct {
+	APICalls int
+	// ByClass 按状态码类别（2xx、4xx、5xx、error）统计 API 调用
+	ByClass  map[string]int
+	Retries  int
+	WaitTime time.Duration
+	Timeouts int
+}
+
+// Totals 返回 API 调用、重试和等待时间的合计
+func (r *Registry) Totals() Totals {
+	totals := Totals{ByClass: make(map[string]int)}
+	if r == nil {
+		return totals
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, n := range r.apiCalls {
+		totals.APICalls += n
+		class := key.status
+		if class != "error" {
+			class = class[:1] + "xx"
+		}
+		totals.ByClass[class] += n
+	}
+	for _, n := range r.retries {
+		totals.Retries += n
+	}
+	for _, h := range r.waits {
+		totals.WaitTime += time.Duration(h.sum * float64(time.Second))
+	}
+	for _, n := range r.waitTimeouts {
+		totals.Timeouts += n
+	}
+	return totals
+}
+
+// WritePrometheus 以 Prometheus 文本格式写出所有指标
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	writeCounter(&b, "api_requests_total", "GitLab API requests by endpoint (client method) and HTTP status; status is \"error\" when no response was received.",
+		r.apiCalls, func(k apiKey) []string { return []string{"endpoint", k.endpoint, "status", k.status} })
+	writeHistograms(&b, "api_request_duration_seconds", "Duration of single GitLab API requests.",
+		r.apiDurations, func(endpoint string) []string { return []string{"endpoint", endpoint} })
+	writeCounter(&b, "api_retries_total", "Retries of GitLab API calls after transient errors.",
+		r.retries, func(endpoint string) []string { return []string{"endpoint", endpoint} })
+	writeCounter(&b, "resources_total", "Resource operations by kind, action and status.", r.resources, resourceLabels)
+	writeHistograms(&b, "resource_duration_seconds", "Duration of single resource operations, including retries.", r.durations, resourceLabels)
+	writeHistograms(&b, "verification_wait_seconds", "Time spent waiting for GitLab to finish asynchronous deletions.",
+		r.waits, func(kind string) []string { return []string{"kind", kind} })
+	writeCounter(&b, "verification_timeouts_total", "Waits for GitLab to finish a deletion that timed out.",
+		r.waitTimeouts, func(kind string) []string { return []string{"kind", kind} })
+	if r.run != nil {
+		labels := formatLabels([]string{"action", r.run.action})
+		success := 0
+		if r.run.success {
+			success = 1
+		}
+		writeGauge(&b, "run_duration_seconds", "Duration of the last run.", labels, r.run.duration.Seconds())
+		writeGauge(&b, "run_success", "Whether the last run succeeded (1) or failed (0).", labels, float64(success))
+		writeGauge(&b, "run_timestamp_seconds", "Unix time when the last run finished.", labels, float64(r.run.finished.Unix()))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// histogram 是累积前的直方图：counts[i] 是落在 (buckets[i-1], buckets[i]] 中的观测数，
+// 最后一个元素是超过所有上界的观测数
+type histogram struct {
+	buckets []float64
+	counts  []int
+	sum     float64
+	count   int
+}
+
+func observe[K comparable](histograms map[K]*histogram, key K, buckets []float64, d time.Duration) {
+	h := histograms[key]
+	if h == nil {
+		h = &histogram{buckets: buckets, counts: make([]int, len(buckets)+1)}
+		histograms[key] = h
+	}
+	seconds := d.Seconds()
+	i, _ := slices.BinarySearch(h.buckets, seconds)
+	h.counts[i]++
+	h.sum += seconds
+	h.count++
+}
+
+func writeHeader(b *strings.Builder, name, help, typ string) {
+	fmt.Fprintf(b, "# HELP %s%s %s\n# TYPE %s%s %s\n", Prefix, name, help, Prefix, name, typ)
+}
+
+// writeCounter 按标签排序写出计数器，没有数据时不输出
+func writeCounter[K comparable](b *strings.Builder, name, help string, values map[K]int, labels func(K) []string) {
+	if len(values) == 0 {
+		return
+	}
+	writeHeader(b, name, help, "counter")
+	lines := make([]string, 0, len(values))
+	for key, n := range values {
+		lines = append(lines, fmt.Sprintf("%s%s%s %d\n", Prefix, name, formatLabels(labels(key)), n))
+	}
+	slices.Sort(lines)
+	for _, line := range lines {
+		b.WriteString(line)
+	}
+}
+
+// writeHistograms 按标签排序写出直方图，没有数据时不输出
+func writeHistograms[K comparable](b *strings.Builder, name, help string, histograms map[K]*histogram, labels func(K) []string) {
+	if len(histograms) == 0 {
+		return
+	}
+	writeHeader(b, name, help, "histogram")
+	keys := make([]K, 0, len(histograms))
+	for key := range histograms {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return strings.Compare(formatLabels(labels(a)), formatLabels(labels(b)))
+	})
+	for _, key := range keys {
+		h := histograms[key]
+		pairs := labels(key)
+		cumulative := 0
+		for i, upper := range h.buckets {
+			cumulative += h.counts[i]
+			le := strconv.FormatFloat(upper, 'f', -1, 64)
+			fmt.Fprintf(b, "%s%s_bucket%s %d\n", Prefix, name, formatLabels(append(slices.Clip(pairs), "le", le)), cumulative)
+		}
+		fmt.Fprintf(b, "%s%s_bucket%s %d\n", Prefix, name, formatLabels(append(slices.Clip(pairs), "le", "+Inf")), h.count)
+		fmt.Fprintf(b, "%s%s_sum%s %s\n", Prefix, name, formatLabels(pairs), strconv.FormatFloat(h.sum, 'f', -1, 64))
+		fmt.Fprintf(b, "%s%s_count%s %d\n", Prefix, name, formatLabels(pairs), h.count)
+	}
+}
+
+// resourceLabels 返回资源操作指标的标签
+func resourceLabels(k resourceKey) []string {
+	return []string{"kind", string(k.kind), "action", string(k.action), "status", string(k.status)}
+}
+
+func writeGauge(b *strings.Builder, name, help, labels string, value float64) {
+	writeHeader(b, name, help, "gauge")
+	fmt.Fprintf(b, "%s%s%s %s\n", Prefix, name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels 把 name, value 交替排列的列表格式化为 {name="value",...}
+func formatLabels(pairs []string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, pairs[i], labelEscaper.Replace(pairs[i+1])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
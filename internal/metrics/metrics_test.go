@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab-cli-sdk/internal/result"
+)
+
+func TestRegistry(t *testing.T) {
+	FromContext(context.Background()).ObserveAPICall("GetUser", 200, time.Second)
+
+	r := New()
+	r.ObserveAPICall("CreateProject", 500, 300*time.Millisecond)
+	r.ObserveAPICall("CreateProject", 201, 2*time.Second)
+	r.ObserveAPICall("GetUser", 0, 40*time.Millisecond)
+	r.ObserveRetry("CreateProject")
+	r.ObserveResource(result.KindProject, result.ActionCreate, result.StatusSucceeded, 3*time.Second)
+	r.ObserveWait(result.KindGroup, 12*time.Second, false)
+	r.ObserveWait(result.KindUser, 700*time.Second, true)
+
+	recorder := result.NewRecorder("bot")
+	recorder.Succeeded(result.KindProject, "bot/demo", result.ActionCreate, time.Now())
+	recorder.Failed(result.KindGroup, "team", result.ActionCreate, time.Now(), errors.New("403 Forbidden"))
+	r.ObserveSummary(result.NewSummary(recorder))
+	r.ObserveRun(result.ActionCreate, 3*time.Second, nil)
+
+	totals := r.Totals()
+	if totals.APICalls != 3 || totals.ByClass["2xx"] != 1 || totals.ByClass["5xx"] != 1 || totals.ByClass["error"] != 1 {
+		t.Errorf("Totals API calls = %d %v, want 3 split across 2xx, 5xx and error", totals.APICalls, totals.ByClass)
+	}
+	if totals.Retries != 1 || totals.WaitTime != 712*time.Second || totals.Timeouts != 1 {
+		t.Errorf("Totals = %+v, want 1 retry, 712s of waits and 1 timeout", totals)
+	}
+
+	var b strings.Builder
+	if err := r.WritePrometheus(&b); err != nil {
+		t.Fatalf("WritePrometheus: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE gitlab_cli_api_requests_total counter\n",
+		`gitlab_cli_api_requests_total{endpoint="CreateProject",status="500"} 1` + "\n",
+		`gitlab_cli_api_requests_total{endpoint="GetUser",status="error"} 1` + "\n",
+		`gitlab_cli_api_request_duration_seconds_bucket{endpoint="CreateProject",le="0.25"} 0` + "\n",
+		`gitlab_cli_api_request_duration_seconds_bucket{endpoint="CreateProject",le="0.5"} 1` + "\n",
+		`gitlab_cli_api_request_duration_seconds_bucket{endpoint="CreateProject",le="+Inf"} 2` + "\n",
+		`gitlab_cli_api_request_duration_seconds_sum{endpoint="CreateProject"} 2.3` + "\n",
+		`gitlab_cli_api_retries_total{endpoint="CreateProject"} 1` + "\n",
+		`gitlab_cli_resources_total{kind="group",action="create",status="failed"} 1` + "\n",
+		`gitlab_cli_resource_duration_seconds_bucket{kind="project",action="create",status="succeeded",le="2.5"} 0` + "\n",
+		`gitlab_cli_resource_duration_seconds_count{kind="project",action="create",status="succeeded"} 1` + "\n",
+		`gitlab_cli_verification_wait_seconds_bucket{kind="user",le="600"} 0` + "\n",
+		`gitlab_cli_verification_wait_seconds_bucket{kind="user",le="+Inf"} 1` + "\n",
+		`gitlab_cli_verification_timeouts_total{kind="user"} 1` + "\n",
+		`gitlab_cli_run_success{action="create"} 1` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+package naming
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Built-in naming strategies, selected with nameMode on a user, group or project.
+const (
+	// ModePrefix appends the run ID: tektoncd → tektoncd-20251030150000123-a1b2.
+	ModePrefix = "prefix"
+	// ModeName uses the configured name verbatim.
+	ModeName = "name"
+	// ModeSequence appends the 1-based position in the config: user → user-001.
+	ModeSequence = "sequence"
+	// ModeUUID appends a random UUID.
+	ModeUUID = "uuid"
+	// ModeHash appends a hash of the seed and the logical name, so reruns produce the same names.
+	ModeHash = "hash"
+	// ModeTemplate renders nameTemplate, e.g. {{.Prefix}}-{{.RunID}}-{{.Index}}.
+	ModeTemplate = "template"
+)
+
+// Kind identifies what a generated name is used for; each kind has its own GitLab rules.
+type Kind string
+
+const (
+	KindUsername Kind = "username"
+	KindEmail    Kind = "email"
+	KindGroup    Kind = "group"
+	KindProject  Kind = "project"
+)
+
+const (
+	// maxNameLength is the GitLab limit for usernames and group/project paths.
+	maxNameLength = 255
+	// maxEmailLocalLength is the RFC 5321 limit for the local part of an email address.
+	maxEmailLocalLength = 64
+	// hashLength is the number of hex characters the hash strategy appends.
+	hashLength = 10
+	// fallbackEmailDomain is used when the configured email has no domain.
+	fallbackEmailDomain = "test.example.com"
+)
+
+var (
+	// usernameInvalidChars matches characters GitLab does not allow in usernames.
+	usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+	// pathInvalidChars matches characters not allowed in generated group and project paths.
+	pathInvalidChars = regexp.MustCompile(`[^a-z0-9_.-]`)
+	// configuredPathInvalidChars matches characters GitLab does not allow in group and project
+	// paths; configured paths may be mixed case.
+	configuredPathInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+)
+
+// Spec is the naming configuration of one resource after inheritance.
+type Spec struct {
+	Mode     string // Mode is the strategy name; empty means prefix
+	Template string // Template is the Go template used by the template strategy
+	Seed     string // Seed makes the hash strategy produce different names for different seeds
+}
+
+// Inherit returns the spec of a child resource: non-empty child settings override the parent.
+func (s Spec) Inherit(mode, template, seed string) Spec {
+	if mode != "" {
+		s.Mode = mode
+	}
+	if template != "" {
+		s.Template = template
+	}
+	if seed != "" {
+		s.Seed = seed
+	}
+	return s
+}
+
+// Request describes the resource a name is generated for.
+type Request struct {
+	Kind   Kind   // Kind selects the GitLab rules applied to the result
+	Prefix string // Prefix is the name configured in the config (username, email, path)
+	Key    string // Key identifies the resource in the config, e.g. alice/backend/demo
+	User   string // User is the configured username the resource belongs to
+	Index  int    // Index is the 1-based position of the resource among its siblings
+	// RunID, Seed and Template are filled in by the Namer from the run and the Spec.
+	RunID    string
+	Seed     string
+	Template string
+}
+
+// Strategy generates names for one nameMode.
+type Strategy interface {
+	// Generate returns the raw name for req; the Namer makes it GitLab-valid afterwards.
+	Generate(req Request) (string, error)
+	// Derivable reports whether the same name can be computed again later from the config alone,
+	// which is what cleanup relies on.
+	Derivable(req Request) bool
+}
+
+// Namer generates the names of one run. It is safe for concurrent use.
+type Namer struct {
+	runID      string
+	mu         sync.RWMutex
+	strategies map[string]Strategy
+}
+
+// New creates a Namer for the run runID with all built-in strategies registered.
+func New(runID string) *Namer {
+	n := &Namer{runID: runID, strategies: make(map[string]Strategy)}
+	n.Register(ModePrefix, prefixStrategy{})
+	n.Register(ModeName, nameStrategy{})
+	n.Register(ModeSequence, sequenceStrategy{})
+	n.Register(ModeUUID, &uuidStrategy{})
+	n.Register(ModeHash, hashStrategy{})
+	n.Register(ModeTemplate, &templateStrategy{})
+	return n
+}
+
+// Register adds or replaces the strategy selected by mode.
+func (n *Namer) Register(mode string, strategy Strategy) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.strategies[mode] = strategy
+}
+
+// RunID returns the run ID names are generated for.
+func (n *Namer) RunID() string {
+	return n.runID
+}
+
+// Generate returns a GitLab-valid name for req using the strategy selected by spec.
+// Names in name mode are returned unchanged, or rejected when GitLab would not accept them.
+func (n *Namer) Generate(spec Spec, req Request) (string, error) {
+	strategy, req, err := n.prepare(spec, req)
+	if err != nil {
+		return "", err
+	}
+	if spec.Mode == ModeName {
+		return req.Prefix, checkConfigured(req.Kind, req.Prefix)
+	}
+	if req.Kind == KindEmail {
+		return generateEmail(strategy, req)
+	}
+	name, err := strategy.Generate(req)
+	if err != nil {
+		return "", err
+	}
+	return finish(req.Kind, name)
+}
+
+// Derive recomputes the name Generate produced for req in an earlier run. ok is false when the
+// strategy cannot reproduce it, for example uuid, or prefix without a run ID.
+func (n *Namer) Derive(spec Spec, req Request) (name string, ok bool, err error) {
+	strategy, prepared, err := n.prepare(spec, req)
+	if err != nil {
+		return "", false, err
+	}
+	if !strategy.Derivable(prepared) {
+		return "", false, nil
+	}
+	name, err = n.Generate(spec, req)
+	return name, err == nil, err
+}
+
+// prepare looks up the strategy and fills in the run-wide fields of req.
+func (n *Namer) prepare(spec Spec, req Request) (Strategy, Request, error) {
+	mode := spec.Mode
+	if mode == "" {
+		mode = ModePrefix
+	}
+
+	n.mu.RLock()
+	strategy, ok := n.strategies[mode]
+	n.mu.RUnlock()
+	if !ok {
+		return nil, req, fmt.Errorf("unknown nameMode %q", mode)
+	}
+
+	req.RunID = n.runID
+	req.Seed = spec.Seed
+	req.Template = spec.Template
+	return strategy, req, nil
+}
+
+// generateEmail applies the strategy to the local part and keeps the domain.
+func generateEmail(strategy Strategy, req Request) (string, error) {
+	localPart, domain, ok := strings.Cut(req.Prefix, "@")
+	if !ok {
+		// Fall back to a default test domain when input is not an email.
+		domain = fallbackEmailDomain
+	}
+	req.Prefix = localPart
+	name, err := strategy.Generate(req)
+	if err != nil {
+		return "", err
+	}
+	name, err = finish(KindEmail, name)
+	if err != nil {
+		return "", err
+	}
+	return name + "@" + domain, nil
+}
+
+// withSuffix joins prefix and suffix, shortening the prefix rather than the suffix when the
+// result would be too long, so generated names stay unique.
+func withSuffix(kind Kind, prefix, suffix string) string {
+	limit := maxLength(kind) - len(suffix) - 1
+	prefix = sanitize(kind, prefix)
+	if limit < 1 {
+		return suffix
+	}
+	if len(prefix) > limit {
+		prefix = strings.TrimRight(prefix[:limit], "-.")
+	}
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + "-" + suffix
+}
+
+// finish sanitizes and truncates name according to the rules of kind.
+func finish(kind Kind, name string) (string, error) {
+	name = sanitize(kind, name)
+	if len(name) > maxLength(kind) {
+		name = strings.TrimRight(name[:maxLength(kind)], "-.")
+	}
+	// GitLab reserves these endings for repository URLs.
+	for _, reserved := range []string{".git", ".atom"} {
+		if strings.HasSuffix(name, reserved) {
+			name = strings.TrimSuffix(name, reserved)
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf("generated %s is empty", kind)
+	}
+	return name, nil
+}
+
+// checkConfigured reports why a name used verbatim in name mode is not valid for kind.
+func checkConfigured(kind Kind, name string) error {
+	var invalid *regexp.Regexp
+	switch kind {
+	case KindGroup, KindProject:
+		invalid = configuredPathInvalidChars
+	case KindUsername:
+		invalid = usernameInvalidChars
+	default:
+		return nil
+	}
+	switch {
+	case name == "":
+		return fmt.Errorf("%s is empty", kind)
+	case invalid.MatchString(name):
+		return fmt.Errorf("%s %q contains characters GitLab does not allow", kind, name)
+	case strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") || strings.HasSuffix(name, "."):
+		return fmt.Errorf("%s %q must not start or end with - or end with .", kind, name)
+	case strings.HasSuffix(name, ".git") || strings.HasSuffix(name, ".atom"):
+		return fmt.Errorf("%s %q must not end with .git or .atom", kind, name)
+	case len(name) > maxLength(kind):
+		return fmt.Errorf("%s %q is longer than %d characters", kind, name, maxLength(kind))
+	}
+	return nil
+}
+
+// sanitize removes characters GitLab does not allow for kind.
+func sanitize(kind Kind, name string) string {
+	switch kind {
+	case KindGroup, KindProject:
+		name = pathInvalidChars.ReplaceAllString(strings.ToLower(name), "")
+		return strings.Trim(name, "-.")
+	default:
+		name = usernameInvalidChars.ReplaceAllString(name, "")
+		return strings.Trim(name, "-.")
+	}
+}
+
+// maxLength returns the maximum length of a name of kind.
+func maxLength(kind Kind) int {
+	if kind == KindEmail {
+		return maxEmailLocalLength
+	}
+	return maxNameLength
+}
+
+// prefixStrategy appends the run ID.
+type prefixStrategy struct{}
+
+func (prefixStrategy) Generate(req Request) (string, error) {
+	if req.RunID == "" {
+		return "", fmt.Errorf("nameMode %s requires a run ID", ModePrefix)
+	}
+	return withSuffix(req.Kind, req.Prefix, sanitize(req.Kind, req.RunID)), nil
+}
+
+func (prefixStrategy) Derivable(req Request) bool { return req.RunID != "" }
+
+// nameStrategy uses the configured name.
+type nameStrategy struct{}
+
+func (nameStrategy) Generate(req Request) (string, error) { return req.Prefix, nil }
+
+func (nameStrategy) Derivable(Request) bool { return true }
+
+// sequenceStrategy appends the zero-padded position.
+type sequenceStrategy struct{}
+
+func (sequenceStrategy) Generate(req Request) (string, error) {
+	return withSuffix(req.Kind, req.Prefix, fmt.Sprintf("%03d", req.Index)), nil
+}
+
+func (sequenceStrategy) Derivable(Request) bool { return true }
+
+// uuidStrategy appends a random UUID. The username and the email of one user share the same
+// UUID because they share the request key.
+type uuidStrategy struct {
+	mu    sync.Mutex
+	uuids map[string]string
+}
+
+func (s *uuidStrategy) Generate(req Request) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.uuids[req.Key]
+	if !ok {
+		var err error
+		if id, err = newUUID(); err != nil {
+			return "", err
+		}
+		if s.uuids == nil {
+			s.uuids = make(map[string]string)
+		}
+		s.uuids[req.Key] = id
+	}
+	return withSuffix(req.Kind, req.Prefix, id), nil
+}
+
+func (s *uuidStrategy) Derivable(Request) bool { return false }
+
+// newUUID returns a random version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate uuid: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b[:])
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]), nil
+}
+
+// hashStrategy appends a hash of the seed and the request key.
+type hashStrategy struct{}
+
+func (hashStrategy) Generate(req Request) (string, error) {
+	sum := sha256.Sum256([]byte(req.Seed + "\x00" + req.Key))
+	return withSuffix(req.Kind, req.Prefix, hex.EncodeToString(sum[:])[:hashLength]), nil
+}
+
+func (hashStrategy) Derivable(Request) bool { return true }
+
+// templateStrategy renders the nameTemplate of the spec.
+type templateStrategy struct {
+	mu    sync.Mutex
+	cache map[string]*template.Template
+}
+
+// templateData is the data available inside nameTemplate.
+type templateData struct {
+	Prefix string
+	RunID  string
+	Index  int
+	Kind   Kind
+	User   string
+}
+
+func (s *templateStrategy) Generate(req Request) (string, error) {
+	if req.Template == "" {
+		return "", fmt.Errorf("nameMode %s requires nameTemplate", ModeTemplate)
+	}
+	tmpl, err := s.parse(req.Template)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	data := templateData{Prefix: req.Prefix, RunID: req.RunID, Index: req.Index, Kind: req.Kind, User: req.User}
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render nameTemplate: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func (s *templateStrategy) Derivable(req Request) bool {
+	return req.RunID != "" || !strings.Contains(req.Template, "RunID")
+}
+
+// parse returns the parsed template, caching it because it is rendered for every resource.
+func (s *templateStrategy) parse(text string) (*template.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tmpl, ok := s.cache[text]; ok {
+		return tmpl, nil
+	}
+	tmpl, err := template.New("nameTemplate").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse nameTemplate: %w", err)
+	}
+	if s.cache == nil {
+		s.cache = make(map[string]*template.Template)
+	}
+	s.cache[text] = tmpl
+	return tmpl, nil
+}
@@ -0,0 +1,159 @@
+package naming
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// validPath matches the group and project paths GitLab accepts.
+var validPath = regexp.MustCompile(`^[a-z0-9_][a-z0-9_.-]*[a-z0-9_]$`)
+
+func TestNamerStrategies(t *testing.T) {
+	namer := New("20251030150000123-a1b2")
+	req := Request{Kind: KindUsername, Prefix: "tektoncd", Key: "tektoncd", User: "tektoncd", Index: 7}
+
+	tests := []struct {
+		name string
+		spec Spec
+		want string
+	}{
+		{name: "prefix", spec: Spec{Mode: ModePrefix}, want: "tektoncd-20251030150000123-a1b2"},
+		{name: "default is prefix", spec: Spec{}, want: "tektoncd-20251030150000123-a1b2"},
+		{name: "name", spec: Spec{Mode: ModeName}, want: "tektoncd"},
+		{name: "sequence", spec: Spec{Mode: ModeSequence}, want: "tektoncd-007"},
+		{name: "template", spec: Spec{Mode: ModeTemplate, Template: "{{.Prefix}}-{{.RunID}}-{{.Index}}"}, want: "tektoncd-20251030150000123-a1b2-7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := namer.Generate(tt.spec, req)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Generate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHashIsReproducible verifies that the hash strategy depends only on the seed and the key,
+// so a rerun (with a new run ID) generates the same names.
+func TestHashIsReproducible(t *testing.T) {
+	spec := Spec{Mode: ModeHash, Seed: "ci"}
+	req := Request{Kind: KindGroup, Prefix: "backend", Key: "tektoncd/backend", Index: 1}
+
+	first, err := New("run-1").Generate(spec, req)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	second, err := New("run-2").Generate(spec, req)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("hash names differ between runs: %q != %q", first, second)
+	}
+
+	other, _ := New("run-1").Generate(Spec{Mode: ModeHash, Seed: "other"}, req)
+	if other == first {
+		t.Errorf("hash names do not depend on the seed: %q", other)
+	}
+}
+
+// TestUUIDSharedByUsernameAndEmail verifies that the username and email of one user end with
+// the same UUID.
+func TestUUIDSharedByUsernameAndEmail(t *testing.T) {
+	namer := New("run")
+	spec := Spec{Mode: ModeUUID}
+
+	username, err := namer.Generate(spec, Request{Kind: KindUsername, Prefix: "bot", Key: "bot"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	email, err := namer.Generate(spec, Request{Kind: KindEmail, Prefix: "bot@example.com", Key: "bot"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.HasSuffix(email, "@example.com") || strings.TrimSuffix(email, "@example.com") != username {
+		t.Errorf("email %q does not match username %q", email, username)
+	}
+
+	if _, ok, _ := namer.Derive(spec, Request{Kind: KindUsername, Prefix: "bot", Key: "bot"}); ok {
+		t.Errorf("Derive() ok = true for uuid, want false")
+	}
+}
+
+// TestNamesAreGitLabValid verifies that generated paths are sanitized and that truncation to
+// the GitLab limit keeps the unique suffix.
+func TestNamesAreGitLabValid(t *testing.T) {
+	namer := New("20251030150000123-a1b2")
+	long := strings.Repeat("Very Long Group!", 30)
+
+	got, err := namer.Generate(Spec{Mode: ModePrefix}, Request{Kind: KindGroup, Prefix: long})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(got) > maxNameLength {
+		t.Errorf("len(Generate()) = %d, want <= %d", len(got), maxNameLength)
+	}
+	if !validPath.MatchString(got) {
+		t.Errorf("Generate() = %q is not a valid GitLab path", got)
+	}
+	if !strings.HasSuffix(got, "-20251030150000123-a1b2") {
+		t.Errorf("Generate() = %q lost the run ID", got)
+	}
+
+	email, err := namer.Generate(Spec{Mode: ModePrefix}, Request{Kind: KindEmail, Prefix: strings.Repeat("a", 80) + "@example.com"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if local, _, _ := strings.Cut(email, "@"); len(local) > maxEmailLocalLength {
+		t.Errorf("email local part has %d characters, want <= %d", len(local), maxEmailLocalLength)
+	}
+}
+
+// TestNameModeKeepsConfiguredPath verifies that name mode uses a dotted, mixed-case path
+// verbatim, that generated paths keep the dots, and that paths GitLab rejects are errors.
+func TestNameModeKeepsConfiguredPath(t *testing.T) {
+	namer := New("run")
+	spec := Spec{Mode: ModeName}
+
+	got, err := namer.Generate(spec, Request{Kind: KindGroup, Prefix: "My.Group_v2"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got != "My.Group_v2" {
+		t.Errorf("Generate() = %q, want My.Group_v2", got)
+	}
+
+	got, err = namer.Generate(Spec{Mode: ModePrefix}, Request{Kind: KindProject, Prefix: "My.Project"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if got != "my.project-run" {
+		t.Errorf("Generate(prefix) = %q, want my.project-run", got)
+	}
+
+	for _, path := range []string{"", "my group", "-team", "team.", "repo.git", "feed.atom"} {
+		if _, err := namer.Generate(spec, Request{Kind: KindProject, Prefix: path}); err == nil {
+			t.Errorf("Generate(%q) error = nil, want an error", path)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	namer := New("")
+	req := Request{Kind: KindUsername, Prefix: "bot"}
+
+	for _, spec := range []Spec{
+		{Mode: "random"},
+		{Mode: ModePrefix},
+		{Mode: ModeTemplate},
+		{Mode: ModeTemplate, Template: "{{.Missing}}"},
+	} {
+		if _, err := namer.Generate(spec, req); err == nil {
+			t.Errorf("Generate(%+v) error = nil, want an error", spec)
+		}
+	}
+}
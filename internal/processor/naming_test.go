@@ -0,0 +1,55 @@
+package processor
+
+import (
+	"testing"
+
+	"gitlab-cli-sdk/internal/naming"
+	"gitlab-cli-sdk/internal/utils"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// TestSharedGroupNames verifies that a group defined by several users gets a path per user that
+// depends only on the config, still ends in the run ID and is derived again by cleanup.
+func TestSharedGroupNames(t *testing.T) {
+	const runID = "20251030150000123-ci01"
+	users := []types.UserSpec{
+		{Username: "alice", Groups: []types.GroupSpec{{Name: "dev"}, {Name: "docs"}}},
+		{Username: "bob", Groups: []types.GroupSpec{{Name: "dev"}, {Name: "ops", NameMode: naming.ModeName}}},
+		{Username: "carol", Groups: []types.GroupSpec{{Name: "ops", NameMode: naming.ModeName}}},
+	}
+	p := &ResourceProcessor{Namer: naming.New(runID), SharedGroups: SharedGroupPaths(users)}
+
+	tests := []struct {
+		user  types.UserSpec
+		index int
+		want  []string
+	}{
+		{user: users[0], index: 1, want: []string{"dev-alice-" + runID, "docs-" + runID}},
+		{user: users[1], index: 2, want: []string{"dev-bob-" + runID, "ops"}},
+	}
+	for _, tt := range tests {
+		for j, groupSpec := range tt.user.Groups {
+			spec := userNamingSpec(tt.user).Inherit(groupSpec.NameMode, "", "")
+			created, err := p.generateName(tt.user.Username, "group:"+groupSpec.Name, spec, p.groupRequest(spec, tt.user.Username, groupSpec.Name, j+1))
+			if err != nil {
+				t.Fatalf("generateName: %v", err)
+			}
+			if created != tt.want[j] {
+				t.Errorf("group %s of %s = %q, want %q", groupSpec.Name, tt.user.Username, created, tt.want[j])
+			}
+			if spec.Mode == naming.ModePrefix && !utils.HasRunID(created, runID) {
+				t.Errorf("group %q does not end in the run ID", created)
+			}
+		}
+
+		resolved, err := p.resolveNames(tt.index, tt.user)
+		if err != nil {
+			t.Fatalf("resolveNames: %v", err)
+		}
+		for j, groupSpec := range resolved.Groups {
+			if groupSpec.Path != tt.want[j] {
+				t.Errorf("cleanup derived %q for group %s of %s, want %q", groupSpec.Path, tt.user.Groups[j].Name, tt.user.Username, tt.want[j])
+			}
+		}
+	}
+}
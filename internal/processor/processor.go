@@ -1,10 +1,21 @@
 package processor
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"gitlab-cli-sdk/internal/checkpoint"
+	"gitlab-cli-sdk/internal/events"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/metrics"
+	"gitlab-cli-sdk/internal/naming"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/tracing"
 	"gitlab-cli-sdk/internal/utils"
 	"gitlab-cli-sdk/pkg/client"
 	"gitlab-cli-sdk/pkg/types"
@@ -14,8 +25,38 @@ import (
 type ResourceProcessor struct {
 	// Client handles GitLab API interactions.
 	Client *client.GitLabClient
-	// NameSuffix optionally overrides the random suffix appended in prefix mode.
-	NameSuffix string
+	// Namer generates resource names according to each spec's nameMode. During cleanup it derives
+	// the names generated by an earlier run from the original config.
+	Namer *naming.Namer
+	// SharedGroups holds the group paths defined by more than one user of the config, see SharedGroupPaths.
+	SharedGroups map[string]bool
+	// Checkpoint records generated names and progress so an interrupted run can resume; nil disables it.
+	Checkpoint *checkpoint.Checkpoint
+	// Logger receives progress messages; nil falls back to slog.Default().
+	Logger *slog.Logger
+	// WaitTimeout bounds each wait for GitLab to finish an asynchronous deletion; zero waits until cancelled.
+	WaitTimeout time.Duration
+	// Results records the outcome of every resource operation; nil disables recording.
+	Results *result.Recorder
+	// Events receives a machine-readable event for every resource operation; nil disables events.
+	Events *events.Stream
+}
+
+// ForUser 返回处理单个用户时使用的处理器副本，日志和结果分别写入该用户的日志器和记录器，
+// 供并发处理多个用户时区分归属
+func (p *ResourceProcessor) ForUser(logger *slog.Logger, results *result.Recorder) *ResourceProcessor {
+	userProc := *p
+	userProc.Logger = logger
+	userProc.Results = results
+	return &userProc
+}
+
+// logger 返回输出进度日志的日志器
+func (p *ResourceProcessor) logger() *slog.Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return slog.Default()
 }
 
 // ========================================
@@ -23,53 +64,87 @@ type ResourceProcessor struct {
 // ========================================
 
 // ProcessUserCreation 处理单个用户的创建流程
-func (p *ResourceProcessor) ProcessUserCreation(userSpec types.UserSpec) (*types.UserOutput, error) {
-	// 确定 nameMode
-	nameMode := userSpec.NameMode
-	if nameMode == "" {
-		nameMode = "prefix" // 默认为 prefix 模式
-	}
-
-	// 根据 nameMode 生成实际的 username 和 email
-	var actualUsername, actualEmail string
-	if nameMode == "name" {
-		// name 模式：直接使用配置文件中的名称
-		actualUsername = userSpec.Username
-		actualEmail = userSpec.Email
-		log.Printf("  使用 name 模式（不添加时间戳）\n")
-	} else {
-		// prefix 模式：添加毫秒时间戳和后缀
-		actualUsername = utils.GenerateUsernameWithTimestamp(userSpec.Username, p.NameSuffix)
-		actualEmail = utils.GenerateEmailWithTimestamp(userSpec.Email, p.NameSuffix)
-		log.Printf("  使用 prefix 模式（添加毫秒时间戳+后缀）\n")
+// 用户本身创建失败时返回错误；组、项目和 Token 的失败记录到 Results 中，不中断该用户的其余资源
+// index 是用户在配置文件中的位置（从 1 开始），供 sequence 和 template 命名策略使用
+func (p *ResourceProcessor) ProcessUserCreation(ctx context.Context, index int, userSpec types.UserSpec) (*types.UserOutput, error) {
+	ctx, span := tracing.Start(ctx, "user", slog.String(logging.KeyUser, userSpec.Username), slog.String(logging.KeyAction, string(result.ActionCreate)))
+	output, err := p.processUserCreation(ctx, index, userSpec)
+	span.End(err)
+	return output, err
+}
+
+func (p *ResourceProcessor) processUserCreation(ctx context.Context, index int, userSpec types.UserSpec) (*types.UserOutput, error) {
+	userNaming := userNamingSpec(userSpec)
+
+	// userKey 是配置文件中的逻辑用户名，用于在断点文件中定位该用户
+	userKey := userSpec.Username
+	// previous 是上次中断的运行为该用户记录的输出（没有断点文件时为 nil）
+	previous, _ := p.Checkpoint.Output(userKey)
+
+	// 根据命名策略生成实际的 username 和 email（断点续跑时复用上次生成的名称）
+	p.logger().Debug(i18n.T("命名策略"), "mode", userNaming.Mode)
+	userReq := naming.Request{Prefix: userSpec.Username, Key: userKey, User: userKey, Index: index}
+	userReq.Kind = naming.KindUsername
+	actualUsername, err := p.generateName(userKey, "username", userNaming, userReq)
+	if err != nil {
+		return nil, err
+	}
+	userReq.Kind = naming.KindEmail
+	userReq.Prefix = userSpec.Email
+	actualEmail, err := p.generateName(userKey, "email", userNaming, userReq)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("  用户名: %s\n", actualUsername)
-	log.Printf("  邮箱: %s\n", actualEmail)
+	p.logger().Info(i18n.T("生成用户名"), "username", actualUsername, "email", actualEmail)
+	tracing.FromContext(ctx).SetAttributes(slog.String("username", actualUsername))
+	p.Results.SetUser(actualUsername)
+	p.Events.Emit(events.Event{Type: events.TypeStart, Action: result.ActionCreate, Kind: result.KindUser, User: actualUsername, Name: userSpec.Username})
 
 	output := &types.UserOutput{
-		Username: actualUsername,
-		Email:    actualEmail,
-		Name:     userSpec.Name,
-		Password: userSpec.Password, // 保存密码到输出
+		Username:    actualUsername,
+		LogicalName: userSpec.Username,
+		Email:       actualEmail,
+		Name:        userSpec.Name,
+		Password:    userSpec.Password, // 保存密码到输出
 	}
+	redact.Add(userSpec.Password)
 
 	// 1. 创建或获取用户
-	userID, err := p.ensureUser(userSpec, actualUsername, actualEmail)
+	started := time.Now()
+	userID, existed, err := p.ensureUser(ctx, userSpec, actualUsername, actualEmail)
+	p.record(ctx, nil, actualUsername, events.Event{Type: createdOrExists(existed), Action: result.ActionCreate, Kind: result.KindUser, User: actualUsername, Name: userSpec.Username, ID: userID, Started: started, Err: err})
 	if err != nil {
+		p.skipUserResources(userSpec, i18n.T("用户创建失败"))
 		return nil, err
 	}
 	output.UserID = userID
+	if err := p.Checkpoint.Record(userKey, output); err != nil {
+		return nil, err
+	}
 
 	// 2. 创建 Personal Access Token (如果配置了)
-	if userSpec.Token != nil {
-		log.Printf("  创建 Personal Access Token...\n")
-		tokenValue, actualExpiresAt, err := p.createPersonalAccessToken(userID, actualUsername, userSpec.Token)
+	if previous != nil && previous.Token != nil && userSpec.Token != nil {
+		// 上次运行已创建过 Token，重复创建会产生多余的 Token
+		p.logger().Info(i18n.T("复用断点文件中的 Token"), "expires_at", previous.Token.ExpiresAt)
+		output.Token = previous.Token
+		redact.Add(previous.Token.Value)
+		p.Results.Skipped(result.KindToken, actualUsername, result.ActionCreate, i18n.T("复用断点文件中的 Token"))
+		p.Events.Emit(events.Event{Type: events.TypeExists, Action: result.ActionCreate, Kind: result.KindToken, User: actualUsername, ID: userID})
+	} else if userSpec.Token != nil {
+		p.logger().Info(i18n.T("创建 Personal Access Token"))
+		started := time.Now()
+		tokenCtx, span := tracing.Start(ctx, "token")
+		tokenValue, actualExpiresAt, err := p.createPersonalAccessToken(tokenCtx, userID, actualUsername, userSpec.Token)
 		if err != nil {
-			log.Printf("  ⚠ 创建 Token 失败: %v\n", err)
+			p.logger().Error(i18n.T("创建 Token 失败"), logging.Err(err), logging.Duration(started))
 		} else {
-			log.Printf("  ✓ Token 创建成功\n")
-			log.Printf("  Token Value: %s\n", tokenValue)
+			// 登记后日志中的 Token 会被隐藏，除非指定了 --show-secrets
+			redact.Add(tokenValue)
+			p.logger().Info(i18n.T("Token 创建成功"), "scopes", userSpec.Token.Scope, "expires_at", actualExpiresAt, logging.Duration(started))
+		}
+		p.record(ctx, span, actualUsername, events.Event{Type: events.TypeCreate, Action: result.ActionCreate, Kind: result.KindToken, User: actualUsername, ID: userID, Started: started, Err: err})
+		if err == nil {
 
 			// 保存 Token 信息到输出（使用实际的过期时间）
 			output.Token = &types.TokenOutput{
@@ -77,37 +152,59 @@ func (p *ResourceProcessor) ProcessUserCreation(userSpec types.UserSpec) (*types
 				Scope:     userSpec.Token.Scope,
 				ExpiresAt: actualExpiresAt,
 			}
+			if err := p.Checkpoint.Record(userKey, output); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	// 3. 创建组和项目
 	if len(userSpec.Groups) > 0 {
-		log.Printf("  创建 %d 个组...\n", len(userSpec.Groups))
-		groupOutputs, err := p.createGroupsWithOutput(actualUsername, userSpec.Groups, nameMode)
-		if err != nil {
+		p.logger().Info(i18n.T("创建组"), "count", len(userSpec.Groups))
+		if err := p.createGroupsWithOutput(ctx, userKey, output, userSpec.Groups, userNaming); err != nil {
 			return output, err
 		}
-		output.Groups = groupOutputs
 	}
 
 	// 4. 创建用户级项目（不属于任何组的项目）
 	if len(userSpec.Projects) > 0 {
-		log.Printf("  创建 %d 个用户级项目...\n", len(userSpec.Projects))
-		projectOutputs, err := p.createUserProjectsWithOutput(actualUsername, userSpec.Projects, nameMode)
+		p.logger().Info(i18n.T("创建用户级项目"), "count", len(userSpec.Projects))
+		projectOutputs, err := p.createUserProjectsWithOutput(ctx, userKey, actualUsername, userSpec.Projects, userNaming)
+		output.Projects = projectOutputs
 		if err != nil {
-			log.Printf("  ⚠ 创建用户级项目失败: %v\n", err)
-		} else {
-			output.Projects = projectOutputs
+			p.logger().Warn(i18n.T("创建用户级项目失败"), logging.Err(err))
+			if ctx.Err() != nil {
+				return output, ctx.Err()
+			}
+		}
+		if err := p.Checkpoint.Record(userKey, output); err != nil {
+			return output, err
 		}
 	}
 
 	return output, nil
 }
 
+// skipUserResources 在用户创建失败时把该用户的 Token、组和项目记录为跳过
+func (p *ResourceProcessor) skipUserResources(userSpec types.UserSpec, reason string) {
+	if userSpec.Token != nil {
+		p.Results.Skipped(result.KindToken, userSpec.Username, result.ActionCreate, reason)
+	}
+	for _, groupSpec := range userSpec.Groups {
+		p.Results.Skipped(result.KindGroup, groupSpec.Name, result.ActionCreate, reason)
+		for _, projSpec := range groupSpec.Projects {
+			p.Results.Skipped(result.KindProject, projSpec.Name, result.ActionCreate, reason)
+		}
+	}
+	for _, projSpec := range userSpec.Projects {
+		p.Results.Skipped(result.KindProject, projSpec.Name, result.ActionCreate, reason)
+	}
+}
+
 // createPersonalAccessToken 为用户创建 Personal Access Token，返回 token 值和实际使用的过期时间
-func (p *ResourceProcessor) createPersonalAccessToken(userID int, username string, tokenSpec *types.TokenSpec) (string, string, error) {
-	// 生成 token 名称，格式: username-token-<millisecond-timestamp>-<suffix>
-	tokenName := fmt.Sprintf("%s-token-%s", username, utils.GenerateTemporalSuffix(p.NameSuffix))
+func (p *ResourceProcessor) createPersonalAccessToken(ctx context.Context, userID int, username string, tokenSpec *types.TokenSpec) (string, string, error) {
+	// 生成 token 名称，格式: username-token-<run-id>
+	tokenName := fmt.Sprintf("%s-token-%s", username, p.Namer.RunID())
 
 	// 设置过期时间：如果未指定，默认为第2天
 	expiresAt := tokenSpec.ExpiresAt
@@ -115,64 +212,76 @@ func (p *ResourceProcessor) createPersonalAccessToken(userID int, username strin
 		// 计算第2天的日期（格式: YYYY-MM-DD）
 		tomorrow := time.Now().AddDate(0, 0, 2)
 		expiresAt = tomorrow.Format("2006-01-02")
-		log.Printf("    未指定过期时间，使用默认值: %s (第2天)\n", expiresAt)
+		p.logger().Info(i18n.T("未指定 Token 过期时间，使用默认值（第2天）"), "expires_at", expiresAt)
 	}
 
 	// 调用客户端创建 token
 	tokenValue, err := p.Client.CreatePersonalAccessToken(
+		ctx,
 		userID,
+		username,
 		tokenName,
 		tokenSpec.Scope,
 		expiresAt,
 	)
 	if err != nil {
-		return "", "", err
+		return "", "", &result.ResourceError{Kind: result.KindToken, Name: tokenName, Action: result.ActionCreate, Err: err}
 	}
 
 	return tokenValue, expiresAt, nil
 }
 
-// ensureUser 确保用户存在，如果不存在则创建
-func (p *ResourceProcessor) ensureUser(userSpec types.UserSpec, actualUsername, actualEmail string) (int, error) {
-	existingUser, err := p.Client.GetUser(actualUsername)
+// ensureUser 确保用户存在，如果不存在则创建；existed 表示用户在此之前已存在
+func (p *ResourceProcessor) ensureUser(ctx context.Context, userSpec types.UserSpec, actualUsername, actualEmail string) (userID int, existed bool, err error) {
+	existingUser, err := p.Client.GetUser(ctx, actualUsername)
 	if err != nil {
-		log.Printf("  ⚠ 检查用户失败: %v\n", err)
+		// 查询失败时仍尝试创建：用户若已存在，创建请求会明确失败
+		p.logger().Warn(i18n.T("检查用户失败"), logging.Err(err))
 	}
 
 	if existingUser != nil {
-		log.Printf("  ⚠ 用户 '%s' 已存在 (ID: %d)\n", actualUsername, existingUser.ID)
-		return existingUser.ID, nil
+		p.logger().Warn(i18n.T("用户已存在"), "username", actualUsername, "id", existingUser.ID)
+		return existingUser.ID, true, nil
 	}
 
-	log.Printf("  创建用户: %s\n", actualUsername)
-	user, err := p.Client.CreateUser(actualUsername, actualEmail, userSpec.Name, userSpec.Password)
+	p.logger().Info(i18n.T("创建用户"), "username", actualUsername)
+	started := time.Now()
+	user, err := p.Client.CreateUser(ctx, actualUsername, actualEmail, userSpec.Name, userSpec.Password)
 	if err != nil {
-		return 0, fmt.Errorf("创建用户 %s: %w", actualUsername, err)
+		return 0, false, &result.ResourceError{Kind: result.KindUser, Name: actualUsername, Action: result.ActionCreate, Err: err}
 	}
 
-	log.Printf("  ✓ 用户创建成功 (ID: %d)\n", user.ID)
-	return user.ID, nil
+	p.logger().Info(i18n.T("用户创建成功"), "username", actualUsername, "id", user.ID, logging.Duration(started))
+	return user.ID, false, nil
 }
 
-// createGroupsWithOutput 创建多个组及其项目并返回输出结果
-func (p *ResourceProcessor) createGroupsWithOutput(username string, groups []types.GroupSpec, userNameMode string) ([]types.GroupOutput, error) {
-	var groupOutputs []types.GroupOutput
+// createGroupsWithOutput 创建多个组及其项目，每完成一个组就追加到 output 并写入断点文件
+func (p *ResourceProcessor) createGroupsWithOutput(ctx context.Context, userKey string, output *types.UserOutput, groups []types.GroupSpec, userNaming naming.Spec) error {
+	username := output.Username
 
 	for j, groupSpec := range groups {
-		log.Printf("  ------------------------------------------\n")
-		log.Printf("  处理组 [%d/%d]: %s\n", j+1, len(groups), groupSpec.Name)
-
-		// 确定组的 nameMode（如果组没有指定，则继承用户的 nameMode）
-		groupNameMode := groupSpec.NameMode
-		if groupNameMode == "" {
-			groupNameMode = userNameMode
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+		p.logger().Info(i18n.T("处理组"), logging.KeyGroup, groupSpec.Name, "index", j+1, "total", len(groups))
+
+		// 组未指定的命名设置继承用户的设置
+		groupNaming := userNaming.Inherit(groupSpec.NameMode, groupSpec.NameTemplate, groupSpec.NameSeed)
+		groupPrefix := pathOrName(groupSpec.Path, groupSpec.Name)
 
-		groupID, groupPath, err := p.ensureGroup(username, groupSpec, groupNameMode)
+		started := time.Now()
+		groupCtx, span := tracing.Start(ctx, "group", slog.String(logging.KeyGroup, groupPrefix))
+		groupID, groupPath, existed, err := p.ensureGroup(groupCtx, userKey, username, j+1, groupSpec, groupNaming)
 		if err != nil {
-			log.Printf("    ⚠ 创建组失败 %s: %v\n", groupSpec.Path, err)
+			p.logger().Error(i18n.T("创建组失败"), logging.KeyGroup, groupPrefix, logging.Err(err), logging.Duration(started))
+			p.record(ctx, span, groupSpec.Name, events.Event{Action: result.ActionCreate, Kind: result.KindGroup, User: username, Name: groupSpec.Name, Path: groupPrefix, Started: started, Err: err})
+			for _, projSpec := range groupSpec.Projects {
+				p.Results.Skipped(result.KindProject, projSpec.Name, result.ActionCreate, i18n.T("所属组创建失败"))
+			}
 			continue
 		}
+		// 组的 span 包含其中项目的创建，在项目处理完后结束
+		p.record(ctx, nil, groupPath, events.Event{Type: createdOrExists(existed), Action: result.ActionCreate, Kind: result.KindGroup, User: username, Name: groupSpec.Name, ID: groupID, Path: groupPath, Started: started})
 
 		groupOutput := types.GroupOutput{
 			Name:       groupSpec.Name,
@@ -183,214 +292,238 @@ func (p *ResourceProcessor) createGroupsWithOutput(username string, groups []typ
 
 		// 创建组下的项目
 		if len(groupSpec.Projects) > 0 {
-			log.Printf("    创建 %d 个项目...\n", len(groupSpec.Projects))
-			projectOutputs, err := p.createProjectsWithOutput(username, groupID, groupPath, groupSpec.Projects, groupNameMode)
+			p.logger().Info(i18n.T("创建组内项目"), logging.KeyGroup, groupPath, "count", len(groupSpec.Projects))
+			projectOutputs, err := p.createProjectsWithOutput(groupCtx, userKey, groupPrefix, username, groupID, groupPath, groupSpec.Projects, groupNaming)
 			if err != nil {
-				log.Printf("    ⚠ 创建项目失败: %v\n", err)
+				p.logger().Warn(i18n.T("创建组内项目失败"), logging.KeyGroup, groupPath, logging.Err(err))
 			}
 			groupOutput.Projects = projectOutputs
+			if ctx.Err() != nil {
+				span.End(ctx.Err())
+				return ctx.Err()
+			}
 		}
+		span.End(nil)
 
-		groupOutputs = append(groupOutputs, groupOutput)
+		output.Groups = append(output.Groups, groupOutput)
+		if err := p.Checkpoint.Record(userKey, output); err != nil {
+			return err
+		}
 	}
-	return groupOutputs, nil
+	return nil
 }
 
-// ensureGroup 确保组存在，如果不存在则创建
-func (p *ResourceProcessor) ensureGroup(username string, groupSpec types.GroupSpec, nameMode string) (int, string, error) {
-	// 根据 nameMode 生成实际的 group path
-	var actualGroupPath string
-	if nameMode == "name" {
-		// name 模式：直接使用配置文件中的名称
-		actualGroupPath = groupSpec.Path
-		if actualGroupPath == "" {
-			actualGroupPath = groupSpec.Name
-		}
-		log.Printf("    使用 name 模式，组 path: %s\n", actualGroupPath)
-	} else {
-		// prefix 模式：添加时间戳
-		if groupSpec.Path == "" {
-			actualGroupPath = utils.GenerateGroupPathWithTimestamp(groupSpec.Name, p.NameSuffix)
-		} else {
-			actualGroupPath = utils.GenerateGroupPathWithTimestamp(groupSpec.Path, p.NameSuffix)
-		}
-		log.Printf("    使用 prefix 模式，生成组 path: %s\n", actualGroupPath)
+// ensureGroup 确保组存在，如果不存在则创建；existed 表示组在此之前已存在
+// index 是组在用户配置中的位置（从 1 开始）
+func (p *ResourceProcessor) ensureGroup(ctx context.Context, userKey, username string, index int, groupSpec types.GroupSpec, groupNaming naming.Spec) (groupID int, groupPath string, existed bool, err error) {
+	// 根据命名策略生成实际的 group path
+	groupPrefix := pathOrName(groupSpec.Path, groupSpec.Name)
+	actualGroupPath, err := p.generateName(userKey, "group:"+groupPrefix, groupNaming, p.groupRequest(groupNaming, userKey, groupPrefix, index))
+	if err != nil {
+		return 0, "", false, &result.ResourceError{Kind: result.KindGroup, Name: groupPrefix, Action: result.ActionCreate, Err: err}
 	}
+	p.logger().Debug(i18n.T("生成组 path"), logging.KeyGroup, actualGroupPath, "mode", groupNaming.Mode)
 
-	existingGroup, _ := p.Client.GetGroup(actualGroupPath)
+	existingGroup, err := p.Client.GetGroup(ctx, actualGroupPath)
+	if err != nil {
+		return 0, "", false, &result.ResourceError{Kind: result.KindGroup, Name: actualGroupPath, Action: result.ActionCreate, Err: fmt.Errorf(i18n.T("检查组失败: %w"), err)}
+	}
 
 	if existingGroup != nil {
-		log.Printf("    ⚠ 组 '%s' 已存在 (ID: %d)\n", existingGroup.Path, existingGroup.ID)
-		return existingGroup.ID, existingGroup.Path, nil
+		p.logger().Warn(i18n.T("组已存在"), logging.KeyGroup, existingGroup.Path, "id", existingGroup.ID)
+		return existingGroup.ID, existingGroup.Path, true, nil
 	}
 
-	log.Printf("    创建组: %s (path: %s)\n", groupSpec.Name, actualGroupPath)
+	p.logger().Info(i18n.T("创建组"), logging.KeyGroup, actualGroupPath, "name", groupSpec.Name)
+	started := time.Now()
 	group, err := p.Client.CreateGroup(
+		ctx,
 		username,
 		groupSpec.Name,
 		actualGroupPath,
 		utils.GetVisibility(groupSpec.Visibility),
 	)
 	if err != nil {
-		return 0, "", err
+		return 0, "", false, &result.ResourceError{Kind: result.KindGroup, Name: actualGroupPath, Action: result.ActionCreate, Err: err}
 	}
 
-	log.Printf("    ✓ 组创建成功 (ID: %d, Path: %s)\n", group.ID, group.Path)
-	return group.ID, group.Path, nil
+	p.logger().Info(i18n.T("组创建成功"), logging.KeyGroup, group.Path, "id", group.ID, logging.Duration(started))
+	return group.ID, group.Path, false, nil
 }
 
 // createUserProjectsWithOutput 创建用户级别的项目（不属于任何组）
-func (p *ResourceProcessor) createUserProjectsWithOutput(username string, projects []types.ProjectSpec, userNameMode string) ([]types.ProjectOutput, error) {
-	var projectOutputs []types.ProjectOutput
-
+func (p *ResourceProcessor) createUserProjectsWithOutput(ctx context.Context, userKey, username string, projects []types.ProjectSpec, userNaming naming.Spec) ([]types.ProjectOutput, error) {
 	// 获取用户的 namespace ID
-	namespaceID, err := p.Client.GetUserNamespaceID(username)
+	started := time.Now()
+	namespaceID, err := p.Client.GetUserNamespaceID(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("获取用户 namespace ID 失败: %w", err)
+		err = fmt.Errorf(i18n.T("获取用户 namespace ID 失败: %w"), err)
+		for _, projSpec := range projects {
+			p.record(ctx, nil, projSpec.Name, events.Event{Action: result.ActionCreate, Kind: result.KindProject, User: username, Name: projSpec.Name, Started: started, Err: err})
+		}
+		return nil, err
 	}
 
-	log.Printf("    用户 %s 的 namespace ID: %d\n", username, namespaceID)
+	p.logger().Debug(i18n.T("获取用户 namespace"), "username", username, "namespace_id", namespaceID)
 
-	for _, projSpec := range projects {
-		// 确定项目的 nameMode（如果项目没有指定，则继承用户的 nameMode）
-		projectNameMode := projSpec.NameMode
-		if projectNameMode == "" {
-			projectNameMode = userNameMode
-		}
-
-		// 根据 nameMode 生成实际的 project path
-		var actualProjectPath string
-		if projectNameMode == "name" {
-			// name 模式：直接使用配置文件中的名称
-			actualProjectPath = projSpec.Path
-			if actualProjectPath == "" {
-				actualProjectPath = projSpec.Name
-			}
-			log.Printf("    使用 name 模式，项目 path: %s\n", actualProjectPath)
-		} else {
-			// prefix 模式：添加时间戳
-			if projSpec.Path == "" {
-				actualProjectPath = utils.GenerateProjectPathWithTimestamp(projSpec.Name, p.NameSuffix)
-			} else {
-				actualProjectPath = utils.GenerateProjectPathWithTimestamp(projSpec.Path, p.NameSuffix)
-			}
-			log.Printf("    使用 prefix 模式，生成项目 path: %s\n", actualProjectPath)
+	return p.createProjectsWithOutput(ctx, userKey, "", username, namespaceID, username, projects, userNaming)
+}
+
+// createProjectsWithOutput 在 namespace（组或用户命名空间）下创建多个项目并返回输出结果
+// groupPrefix 是所属组在配置文件中的 path，用户级项目为空。单个项目失败会记录到 Results 并继续处理其余项目
+func (p *ResourceProcessor) createProjectsWithOutput(ctx context.Context, userKey, groupPrefix, username string, namespaceID int, namespacePath string, projects []types.ProjectSpec, parentNaming naming.Spec) ([]types.ProjectOutput, error) {
+	var projectOutputs []types.ProjectOutput
+
+	for k, projSpec := range projects {
+		if err := ctx.Err(); err != nil {
+			return projectOutputs, err
 		}
 
-		// 用户级项目的 full path 是 username/project-path
-		fullPath := fmt.Sprintf("%s/%s", username, actualProjectPath)
-		existingProj, _ := p.Client.GetProject(fullPath)
+		// 项目未指定的命名设置继承上级的设置
+		projectNaming := parentNaming.Inherit(projSpec.NameMode, projSpec.NameTemplate, projSpec.NameSeed)
+		projectPrefix := pathOrName(projSpec.Path, projSpec.Name)
+		started := time.Now()
+		projectCtx, span := tracing.Start(ctx, "project", slog.String(logging.KeyProject, projectPrefix))
+		actualProjectPath, err := p.generateName(userKey, projectNameKey(groupPrefix, projectPrefix), projectNaming, naming.Request{
+			Kind:   naming.KindProject,
+			Prefix: projectPrefix,
+			Key:    projectKey(userKey, groupPrefix, projectPrefix),
+			User:   userKey,
+			Index:  k + 1,
+		})
+		if err != nil {
+			p.logger().Error(i18n.T("生成项目 path 失败"), logging.KeyProject, projSpec.Name, logging.Err(err), logging.Duration(started))
+			p.record(ctx, span, projSpec.Name, events.Event{Action: result.ActionCreate, Kind: result.KindProject, User: username, Name: projSpec.Name, Started: started, Err: err})
+			continue
+		}
+		p.logger().Debug(i18n.T("生成项目 path"), logging.KeyProject, actualProjectPath, "mode", projectNaming.Mode)
 
-		var projectID int
-		var webURL string
+		// 项目的 full path 是 namespace/project-path（用户级项目为 username/project-path）
+		fullPath := fmt.Sprintf("%s/%s", namespacePath, actualProjectPath)
+		span.SetAttributes(slog.String("path", fullPath))
+		existingProj, err := p.Client.GetProject(projectCtx, fullPath)
+		if err != nil {
+			p.logger().Error(i18n.T("检查项目失败"), logging.KeyProject, fullPath, logging.Err(err), logging.Duration(started))
+			err = fmt.Errorf(i18n.T("检查项目失败: %w"), err)
+			p.record(ctx, span, fullPath, events.Event{Action: result.ActionCreate, Kind: result.KindProject, User: username, Name: projSpec.Name, Path: fullPath, Started: started, Err: err})
+			continue
+		}
 
+		projectEvent := events.Event{Type: events.TypeExists, Action: result.ActionCreate, Kind: result.KindProject, User: username, Name: projSpec.Name, Path: fullPath, Started: started}
 		if existingProj != nil {
-			log.Printf("    ⚠ 项目 '%s' 已存在 (ID: %d)\n", projSpec.Name, existingProj.ID)
-			projectID = existingProj.ID
-			webURL = existingProj.WebURL
+			p.logger().Warn(i18n.T("项目已存在"), logging.KeyProject, fullPath, "id", existingProj.ID)
+			projectEvent.ID, projectEvent.WebURL = existingProj.ID, existingProj.WebURL
 		} else {
-			log.Printf("    创建用户级项目: %s (path: %s)\n", projSpec.Name, actualProjectPath)
-			// 用户级项目使用用户的 namespace ID
+			p.logger().Info(i18n.T("创建项目"), logging.KeyProject, fullPath, "name", projSpec.Name)
 			project, err := p.Client.CreateProject(
+				projectCtx,
 				username,
-				namespaceID, // 使用用户的 namespace ID
+				namespaceID,
+				namespacePath,
 				projSpec.Name,
 				actualProjectPath,
 				projSpec.Description,
 				utils.GetVisibility(projSpec.Visibility),
 			)
 			if err != nil {
-				log.Printf("    ⚠ 创建项目失败 %s: %v\n", projSpec.Name, err)
+				p.logger().Error(i18n.T("创建项目失败"), logging.KeyProject, fullPath, logging.Err(err), logging.Duration(started))
+				projectEvent.Err = err
+				p.record(ctx, span, fullPath, projectEvent)
 				continue
 			}
-			log.Printf("    ✓ 项目创建成功 (ID: %d, Path: %s)\n", project.ID, project.PathWithNamespace)
-			projectID = project.ID
-			webURL = project.WebURL
+			p.logger().Info(i18n.T("项目创建成功"), logging.KeyProject, project.PathWithNamespace, "id", project.ID, logging.Duration(started))
+			projectEvent.Type, projectEvent.ID, projectEvent.WebURL = events.TypeCreate, project.ID, project.WebURL
 		}
+		p.record(ctx, span, fullPath, projectEvent)
 
 		projectOutputs = append(projectOutputs, types.ProjectOutput{
 			Name:        projSpec.Name,
 			Path:        fullPath,
 			ProjectPath: actualProjectPath,
-			ProjectID:   projectID,
+			ProjectID:   projectEvent.ID,
 			Description: projSpec.Description,
 			Visibility:  projSpec.Visibility,
-			WebURL:      webURL,
+			WebURL:      projectEvent.WebURL,
 		})
 	}
 	return projectOutputs, nil
 }
 
-// createProjectsWithOutput 创建多个项目并返回输出结果
-func (p *ResourceProcessor) createProjectsWithOutput(username string, groupID int, groupPath string, projects []types.ProjectSpec, groupNameMode string) ([]types.ProjectOutput, error) {
-	var projectOutputs []types.ProjectOutput
+// generateName 按命名策略生成资源名称。name 策略原样使用配置中的名称，其余策略生成的名称
+// 记录在断点文件的 checkpointKey 下，断点续跑时复用
+func (p *ResourceProcessor) generateName(userKey, checkpointKey string, spec naming.Spec, req naming.Request) (string, error) {
+	if spec.Mode == naming.ModeName {
+		return req.Prefix, nil
+	}
+	return p.Checkpoint.Name(userKey, checkpointKey, func() (string, error) {
+		return p.Namer.Generate(spec, req)
+	})
+}
 
-	for _, projSpec := range projects {
-		// 确定项目的 nameMode（如果项目没有指定，则继承组的 nameMode）
-		projectNameMode := projSpec.NameMode
-		if projectNameMode == "" {
-			projectNameMode = groupNameMode
-		}
-
-		// 根据 nameMode 生成实际的 project path
-		var actualProjectPath string
-		if projectNameMode == "name" {
-			// name 模式：直接使用配置文件中的名称
-			actualProjectPath = projSpec.Path
-			if actualProjectPath == "" {
-				actualProjectPath = projSpec.Name
-			}
-			log.Printf("      使用 name 模式，项目 path: %s\n", actualProjectPath)
-		} else {
-			// prefix 模式：添加时间戳
-			if projSpec.Path == "" {
-				actualProjectPath = utils.GenerateProjectPathWithTimestamp(projSpec.Name, p.NameSuffix)
-			} else {
-				actualProjectPath = utils.GenerateProjectPathWithTimestamp(projSpec.Path, p.NameSuffix)
+// groupRequest 返回生成组路径的命名请求，创建和清理时推导名称都使用它。
+// 组路径全局唯一：多个用户定义了同一个组时，把用户的逻辑名并入前缀（dev → dev-alice-<runID>），
+// 生成的路径只取决于配置，与并发处理的先后顺序无关，仍以 run ID 结尾。
+// name 策略按配置原样使用路径，hash 和 uuid 策略生成的名称本来就因用户而不同，不需要并入
+func (p *ResourceProcessor) groupRequest(spec naming.Spec, userKey, groupPrefix string, index int) naming.Request {
+	prefix := groupPrefix
+	switch spec.Mode {
+	case naming.ModeName, naming.ModeHash, naming.ModeUUID:
+	default:
+		if p.SharedGroups[groupPrefix] {
+			prefix = groupPrefix + "-" + userKey
+		}
+	}
+	return naming.Request{Kind: naming.KindGroup, Prefix: prefix, Key: userKey + "/" + groupPrefix, User: userKey, Index: index}
+}
+
+// SharedGroupPaths 返回配置中被多个用户定义的组（按 path，未配置 path 时按 name）
+func SharedGroupPaths(users []types.UserSpec) map[string]bool {
+	owners := make(map[string]map[string]bool)
+	for _, userSpec := range users {
+		for _, groupSpec := range userSpec.Groups {
+			groupPrefix := pathOrName(groupSpec.Path, groupSpec.Name)
+			if owners[groupPrefix] == nil {
+				owners[groupPrefix] = make(map[string]bool)
 			}
-			log.Printf("      使用 prefix 模式，生成项目 path: %s\n", actualProjectPath)
+			owners[groupPrefix][userSpec.Username] = true
+		}
+	}
+	shared := make(map[string]bool)
+	for groupPrefix, users := range owners {
+		if len(users) > 1 {
+			shared[groupPrefix] = true
 		}
+	}
+	return shared
+}
 
-		fullPath := fmt.Sprintf("%s/%s", groupPath, actualProjectPath)
-		existingProj, _ := p.Client.GetProject(fullPath)
+// userNamingSpec 返回用户的命名设置，未指定 nameMode 时默认为 prefix
+func userNamingSpec(userSpec types.UserSpec) naming.Spec {
+	return naming.Spec{Mode: naming.ModePrefix}.Inherit(userSpec.NameMode, userSpec.NameTemplate, userSpec.NameSeed)
+}
 
-		var projectID int
-		var webURL string
+// pathOrName 返回配置的 path，未配置时使用 name
+func pathOrName(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path
+}
 
-		if existingProj != nil {
-			log.Printf("      ⚠ 项目 '%s' 已存在 (ID: %d)\n", projSpec.Name, existingProj.ID)
-			projectID = existingProj.ID
-			webURL = existingProj.WebURL
-		} else {
-			log.Printf("      创建项目: %s (path: %s)\n", projSpec.Name, actualProjectPath)
-			project, err := p.Client.CreateProject(
-				username,
-				groupID,
-				projSpec.Name,
-				actualProjectPath,
-				projSpec.Description,
-				utils.GetVisibility(projSpec.Visibility),
-			)
-			if err != nil {
-				log.Printf("      ⚠ 创建项目失败 %s: %v\n", projSpec.Name, err)
-				continue
-			}
-			log.Printf("      ✓ 项目创建成功 (ID: %d, Path: %s)\n", project.ID, project.PathWithNamespace)
-			projectID = project.ID
-			webURL = project.WebURL
-		}
+// projectNameKey 返回项目在断点文件中的名称键；用户级项目与组内项目分开命名
+func projectNameKey(groupPrefix, projectPrefix string) string {
+	if groupPrefix == "" {
+		return "project:" + projectPrefix
+	}
+	return "project:" + groupPrefix + "/" + projectPrefix
+}
 
-		projectOutputs = append(projectOutputs, types.ProjectOutput{
-			Name:        projSpec.Name,
-			Path:        fullPath,
-			ProjectPath: actualProjectPath,
-			ProjectID:   projectID,
-			Description: projSpec.Description,
-			Visibility:  projSpec.Visibility,
-			WebURL:      webURL,
-		})
+// projectKey 返回项目在配置文件中的逻辑标识，hash 等策略据此生成稳定的名称
+func projectKey(userKey, groupPrefix, projectPrefix string) string {
+	if groupPrefix == "" {
+		return userKey + "/" + projectPrefix
 	}
-	return projectOutputs, nil
+	return userKey + "/" + groupPrefix + "/" + projectPrefix
 }
 
 // ========================================
@@ -399,275 +532,574 @@ func (p *ResourceProcessor) createProjectsWithOutput(username string, groupID in
 
 // ProcessUserCleanup 处理单个用户的清理流程
 // 返回 (deleted bool, error): deleted 表示是否实际删除了用户
-func (p *ResourceProcessor) ProcessUserCleanup(userSpec types.UserSpec, daysOld int) (bool, error) {
-	user, err := p.Client.GetUser(userSpec.Username)
+// index 是用户在配置文件中的位置（从 1 开始），用于推导 sequence 等策略生成的名称
+func (p *ResourceProcessor) ProcessUserCleanup(ctx context.Context, index int, userSpec types.UserSpec, daysOld int) (bool, error) {
+	ctx, span := tracing.Start(ctx, "user", slog.String(logging.KeyUser, userSpec.Username), slog.String(logging.KeyAction, string(result.ActionDelete)))
+	deleted, err := p.processUserCleanup(ctx, index, userSpec, daysOld)
+	span.SetAttributes(slog.Bool("deleted", deleted))
+	span.End(err)
+	return deleted, err
+}
+
+func (p *ResourceProcessor) processUserCleanup(ctx context.Context, index int, userSpec types.UserSpec, daysOld int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	configured := userSpec.Username
+	userSpec, err := p.resolveNames(index, userSpec)
 	if err != nil {
-		log.Printf("  ⚠ 检查用户失败: %v\n", err)
-		return false, nil
+		err = &result.ResourceError{Kind: result.KindUser, Name: configured, Action: result.ActionDelete, Err: err}
+		p.record(ctx, nil, configured, events.Event{Action: result.ActionDelete, Kind: result.KindUser, User: configured, Name: configured, Started: time.Now(), Err: err})
+		return false, err
+	}
+	if userSpec.Username != configured {
+		p.logger().Info(i18n.T("推导出创建时生成的用户名"), "username", userSpec.Username)
+		tracing.FromContext(ctx).SetAttributes(slog.String("username", userSpec.Username))
+	}
+	p.Events.Emit(events.Event{Type: events.TypeStart, Action: result.ActionDelete, Kind: result.KindUser, User: userSpec.Username, Name: configured})
+
+	started := time.Now()
+	user, err := p.Client.GetUser(ctx, userSpec.Username)
+	if err != nil {
+		p.logger().Error(i18n.T("检查用户失败"), "username", userSpec.Username, logging.Err(err), logging.Duration(started))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, ctxErr
+		}
+		err = &result.ResourceError{Kind: result.KindUser, Name: userSpec.Username, Action: result.ActionDelete, Err: fmt.Errorf(i18n.T("检查用户失败: %w"), err)}
+		p.record(ctx, nil, userSpec.Username, events.Event{Action: result.ActionDelete, Kind: result.KindUser, User: userSpec.Username, Started: started, Err: err})
+		return false, err
 	}
 
 	if user == nil {
-		log.Printf("  用户不存在，跳过: %s\n\n", userSpec.Username)
+		p.logger().Info(i18n.T("用户不存在，跳过"), "username", userSpec.Username)
+		p.Results.Skipped(result.KindUser, userSpec.Username, result.ActionDelete, i18n.T("不存在"))
 		return false, nil
 	}
 
-	log.Printf("  找到用户 '%s' (ID: %d, 邮箱: %s)\n", user.Username, user.ID, user.Email)
+	p.logger().Info(i18n.T("找到用户"), "username", user.Username, "id", user.ID, "email", user.Email)
 
 	// 检查用户创建日期
 	if daysOld > 0 {
 		if user.CreatedAt == nil {
-			log.Printf("  ⚠ 无法获取用户创建时间，跳过删除\n\n")
+			p.logger().Warn(i18n.T("无法获取用户创建时间，跳过删除"), "username", userSpec.Username)
+			p.Results.Skipped(result.KindUser, userSpec.Username, result.ActionDelete, i18n.T("无法获取创建时间"))
 			return false, nil
 		}
 
 		createdAt := *user.CreatedAt
 		daysSinceCreation := int(time.Since(createdAt).Hours() / 24)
-		log.Printf("  用户创建于: %s (%d 天前)\n", createdAt.Format("2006-01-02 15:04:05"), daysSinceCreation)
+		p.logger().Info(i18n.T("用户创建时间"), "username", userSpec.Username, "created_at", createdAt.Format("2006-01-02 15:04:05"), "days", daysSinceCreation)
 
 		if daysSinceCreation < daysOld {
-			log.Printf("  ⚠ 用户创建时间未超过 %d 天，跳过删除\n\n", daysOld)
+			p.logger().Info(i18n.T("用户创建时间未超过指定天数，跳过删除"), "username", userSpec.Username, "days_old", daysOld)
+			p.Results.Skipped(result.KindUser, userSpec.Username, result.ActionDelete, fmt.Sprintf(i18n.T("创建未超过 %d 天"), daysOld))
 			return false, nil
 		}
 
-		log.Printf("  ✓ 用户创建时间已超过 %d 天，将进行删除\n", daysOld)
+		p.logger().Info(i18n.T("用户创建时间已超过指定天数，将进行删除"), "username", userSpec.Username, "days_old", daysOld)
 	}
 
 	// 1. 删除用户级项目（不属于任何组的项目）
 	if len(userSpec.Projects) > 0 {
-		log.Printf("  删除用户级项目...\n")
-		p.deleteUserProjects(userSpec.Username)
+		p.logger().Info(i18n.T("删除用户级项目"))
+		p.deleteUserProjects(ctx, userSpec.Username)
 	}
 
 	// 2. 删除配置文件中定义的组和项目
 	if len(userSpec.Groups) > 0 {
-		log.Printf("  删除 %d 个组及其项目...\n", len(userSpec.Groups))
-		p.deleteConfiguredGroups(userSpec.Groups)
+		p.logger().Info(i18n.T("删除组及其项目"), "count", len(userSpec.Groups))
+		p.deleteConfiguredGroups(ctx, userSpec.Username, userSpec.Groups)
 
 		// 验证配置的组已删除
-		if !p.verifyGroupsDeletion(userSpec.Groups, 6, 5*time.Second) {
-			log.Printf("  ⚠ 警告: 部分组可能仍然存在\n")
+		if err := p.verifyGroupsDeletion(ctx, userSpec.Username, userSpec.Groups); err != nil {
+			if !errors.Is(err, utils.ErrWaitTimeout) {
+				return false, err
+			}
+			p.logger().Warn(i18n.T("部分组可能仍然存在"))
 		}
 	}
 
 	// 2. 删除用户拥有的其他组
-	p.deleteUserOwnedGroups(userSpec.Username)
+	if err := p.deleteUserOwnedGroups(ctx, userSpec.Username); err != nil {
+		return false, err
+	}
 
 	// 3. 等待数据同步
-	log.Printf("  等待 GitLab 内部数据同步 (10秒)...\n")
-	time.Sleep(10 * time.Second)
+	if err := p.waitForNamespaceSync(ctx, userSpec.Username); err != nil {
+		return false, err
+	}
 
 	// 4. 删除用户
-	if err := p.deleteUser(user.ID, userSpec.Username); err != nil {
-		log.Printf("  ⚠ 删除用户失败: %v\n\n", err)
+	if err := p.deleteUser(ctx, user.ID, userSpec.Username); err != nil {
+		p.logger().Error(i18n.T("删除用户失败"), logging.Err(err))
 		return false, err
 	}
 
 	return true, nil
 }
 
+// resolveNames 根据命名策略推导创建时生成的用户名、组路径和项目路径，使原始配置文件也能用于清理。
+// 无法推导的名称（uuid 策略，或未指定运行 ID 的 prefix 策略）以及已包含运行 ID 的名称
+// （例如来自输出文件）保持不变
+func (p *ResourceProcessor) resolveNames(index int, userSpec types.UserSpec) (types.UserSpec, error) {
+	if p.Namer == nil {
+		return userSpec, nil
+	}
+	userKey := userSpec.Username
+	userNaming := userNamingSpec(userSpec)
+
+	username, err := p.deriveName(userNaming, naming.Request{Kind: naming.KindUsername, Prefix: userSpec.Username, Key: userKey, User: userKey, Index: index})
+	if err != nil {
+		return userSpec, err
+	}
+	userSpec.Username = username
+
+	groups := make([]types.GroupSpec, len(userSpec.Groups))
+	for j, groupSpec := range userSpec.Groups {
+		groupNaming := userNaming.Inherit(groupSpec.NameMode, groupSpec.NameTemplate, groupSpec.NameSeed)
+		groupPrefix := pathOrName(groupSpec.Path, groupSpec.Name)
+		groupSpec.Path, err = p.deriveName(groupNaming, p.groupRequest(groupNaming, userKey, groupPrefix, j+1))
+		if err != nil {
+			return userSpec, err
+		}
+		if groupSpec.Projects, err = p.resolveProjectNames(userKey, groupPrefix, groupSpec.Projects, groupNaming); err != nil {
+			return userSpec, err
+		}
+		groups[j] = groupSpec
+	}
+	userSpec.Groups = groups
+
+	userSpec.Projects, err = p.resolveProjectNames(userKey, "", userSpec.Projects, userNaming)
+	return userSpec, err
+}
+
+// resolveProjectNames 推导创建时生成的项目路径
+func (p *ResourceProcessor) resolveProjectNames(userKey, groupPrefix string, projects []types.ProjectSpec, parentNaming naming.Spec) ([]types.ProjectSpec, error) {
+	resolved := make([]types.ProjectSpec, len(projects))
+	for k, projSpec := range projects {
+		projectNaming := parentNaming.Inherit(projSpec.NameMode, projSpec.NameTemplate, projSpec.NameSeed)
+		projectPrefix := pathOrName(projSpec.Path, projSpec.Name)
+		path, err := p.deriveName(projectNaming, naming.Request{Kind: naming.KindProject, Prefix: projectPrefix, Key: projectKey(userKey, groupPrefix, projectPrefix), User: userKey, Index: k + 1})
+		if err != nil {
+			return nil, err
+		}
+		projSpec.Path = path
+		resolved[k] = projSpec
+	}
+	return resolved, nil
+}
+
+// deriveName 推导单个名称，无法推导时返回配置中的名称
+func (p *ResourceProcessor) deriveName(spec naming.Spec, req naming.Request) (string, error) {
+	if spec.Mode == naming.ModePrefix && utils.HasRunID(req.Prefix, p.Namer.RunID()) {
+		return req.Prefix, nil
+	}
+	name, ok, err := p.Namer.Derive(spec, req)
+	if err != nil || !ok {
+		return req.Prefix, err
+	}
+	return name, nil
+}
+
+// pollOptions 返回等待 GitLab 异步删除时使用的轮询参数
+func (p *ResourceProcessor) pollOptions() utils.PollOptions {
+	return utils.PollOptions{
+		Interval:    2 * time.Second,
+		MaxInterval: 15 * time.Second,
+		Timeout:     p.WaitTimeout,
+	}
+}
+
 // deleteUserProjects 删除用户级项目
 // 注意：此函数会删除用户命名空间下的所有个人项目（不属于任何组的项目）
-func (p *ResourceProcessor) deleteUserProjects(username string) {
+func (p *ResourceProcessor) deleteUserProjects(ctx context.Context, username string) {
 	// 获取用户拥有的所有项目
-	userProjects, err := p.Client.ListUserProjects(username)
+	started := time.Now()
+	userProjects, err := p.Client.ListUserProjects(ctx, username)
 	if err != nil {
-		log.Printf("  ⚠ 获取用户项目列表失败: %v\n", err)
+		p.logger().Error(i18n.T("获取用户项目列表失败"), "username", username, logging.Err(err), logging.Duration(started))
+		err = fmt.Errorf(i18n.T("获取用户项目列表失败: %w"), err)
+		p.record(ctx, nil, username+"/*", events.Event{Action: result.ActionDelete, Kind: result.KindProject, User: username, Path: username + "/*", Started: started, Err: err})
 		return
 	}
 
 	if len(userProjects) == 0 {
-		log.Printf("  用户没有个人项目\n")
+		p.logger().Info(i18n.T("用户没有个人项目"), "username", username)
 		return
 	}
 
-	log.Printf("  发现用户有 %d 个个人项目，开始删除...\n", len(userProjects))
+	p.logger().Info(i18n.T("删除用户的个人项目"), "username", username, "count", len(userProjects))
 	for i, project := range userProjects {
-		log.Printf("  ------------------------------------------\n")
-		log.Printf("  处理用户级项目 [%d/%d]: %s\n", i+1, len(userProjects), project.Name)
-		log.Printf("    删除项目: %s (ID: %d, Path: %s)\n", project.Name, project.ID, project.PathWithNamespace)
-		if err := p.Client.DeleteProject(project.ID); err != nil {
-			log.Printf("    ⚠ 删除项目失败: %v\n", err)
+		if ctx.Err() != nil {
+			return
+		}
+		p.logger().Info(i18n.T("删除项目"), logging.KeyProject, project.PathWithNamespace, "id", project.ID, "index", i+1, "total", len(userProjects))
+		started := time.Now()
+		projectCtx, span := tracing.Start(ctx, "project", slog.String(logging.KeyProject, project.PathWithNamespace))
+		err := p.Client.DeleteProject(projectCtx, project.ID, project.PathWithNamespace)
+		if err != nil {
+			p.logger().Error(i18n.T("删除项目失败"), logging.KeyProject, project.PathWithNamespace, logging.Err(err), logging.Duration(started))
 		} else {
-			log.Printf("    ✓ 项目删除成功\n")
+			p.logger().Info(i18n.T("项目删除成功"), logging.KeyProject, project.PathWithNamespace, logging.Duration(started))
 		}
+		p.record(ctx, span, project.PathWithNamespace, events.Event{Type: events.TypeDelete, Action: result.ActionDelete, Kind: result.KindProject, User: username, ID: project.ID, Path: project.PathWithNamespace, Started: started, Err: err})
 	}
 }
 
 // deleteConfiguredGroups 删除配置文件中定义的组及其项目
-func (p *ResourceProcessor) deleteConfiguredGroups(groups []types.GroupSpec) {
+func (p *ResourceProcessor) deleteConfiguredGroups(ctx context.Context, username string, groups []types.GroupSpec) {
 	for j, groupSpec := range groups {
-		log.Printf("  ------------------------------------------\n")
-		log.Printf("  处理组 [%d/%d]: %s\n", j+1, len(groups), groupSpec.Name)
+		if ctx.Err() != nil {
+			return
+		}
+		p.logger().Info(i18n.T("处理组"), logging.KeyGroup, groupSpec.Path, "index", j+1, "total", len(groups))
+		groupCtx, span := tracing.Start(ctx, "group", slog.String(logging.KeyGroup, groupSpec.Path))
 
 		// 删除组下的项目
 		if len(groupSpec.Projects) > 0 {
-			log.Printf("    删除 %d 个项目...\n", len(groupSpec.Projects))
-			p.deleteProjects(groupSpec.Path, groupSpec.Projects)
+			p.logger().Info(i18n.T("删除组内项目"), logging.KeyGroup, groupSpec.Path, "count", len(groupSpec.Projects))
+			p.deleteProjects(groupCtx, username, groupSpec.Path, groupSpec.Projects)
 		}
 
 		// 删除组
-		group, _ := p.Client.GetGroup(groupSpec.Path)
-		if group != nil {
-			log.Printf("    删除组: %s (ID: %d)\n", groupSpec.Name, group.ID)
-			if err := p.Client.DeleteGroup(group.ID); err != nil {
-				log.Printf("    ⚠ 删除组失败: %v\n", err)
-			} else {
-				log.Printf("    ✓ 组删除成功\n")
-			}
-		}
+		err := p.deleteConfiguredGroup(groupCtx, username, groupSpec)
+		span.End(err)
 	}
 }
 
+// deleteConfiguredGroup 删除配置文件中定义的单个组，结果记录到 Results；组不存在时不做任何操作
+func (p *ResourceProcessor) deleteConfiguredGroup(ctx context.Context, username string, groupSpec types.GroupSpec) error {
+	started := time.Now()
+	group, err := p.Client.GetGroup(ctx, groupSpec.Path)
+	if err != nil {
+		p.logger().Error(i18n.T("检查组失败"), logging.KeyGroup, groupSpec.Path, logging.Err(err), logging.Duration(started))
+		err = fmt.Errorf(i18n.T("检查组失败: %w"), err)
+		p.record(ctx, nil, groupSpec.Path, events.Event{Action: result.ActionDelete, Kind: result.KindGroup, User: username, Name: groupSpec.Name, Path: groupSpec.Path, Started: started, Err: err})
+		return err
+	}
+	if group == nil {
+		return nil
+	}
+
+	p.logger().Info(i18n.T("删除组"), logging.KeyGroup, groupSpec.Path, "id", group.ID)
+	err = p.Client.DeleteGroup(ctx, group.ID, groupSpec.Path)
+	if err != nil {
+		p.logger().Error(i18n.T("删除组失败"), logging.KeyGroup, groupSpec.Path, logging.Err(err), logging.Duration(started))
+	} else {
+		p.logger().Info(i18n.T("组删除成功"), logging.KeyGroup, groupSpec.Path, logging.Duration(started))
+	}
+	p.record(ctx, nil, groupSpec.Path, events.Event{Type: events.TypeDelete, Action: result.ActionDelete, Kind: result.KindGroup, User: username, Name: groupSpec.Name, ID: group.ID, Path: groupSpec.Path, Started: started, Err: err})
+	return err
+}
+
 // deleteProjects 删除多个项目
-func (p *ResourceProcessor) deleteProjects(groupPath string, projects []types.ProjectSpec) {
+func (p *ResourceProcessor) deleteProjects(ctx context.Context, username, groupPath string, projects []types.ProjectSpec) {
 	for _, projSpec := range projects {
-		fullPath := fmt.Sprintf("%s/%s", groupPath, projSpec.Path)
-		project, _ := p.Client.GetProject(fullPath)
-
-		if project != nil {
-			log.Printf("      删除项目: %s (ID: %d)\n", projSpec.Name, project.ID)
-			if err := p.Client.DeleteProject(project.ID); err != nil {
-				log.Printf("      ⚠ 删除项目失败: %v\n", err)
-			} else {
-				log.Printf("      ✓ 项目删除成功\n")
-			}
+		if ctx.Err() != nil {
+			return
 		}
+		fullPath := fmt.Sprintf("%s/%s", groupPath, projSpec.Path)
+		projectCtx, span := tracing.Start(ctx, "project", slog.String(logging.KeyProject, fullPath))
+		err := p.deleteProject(projectCtx, username, fullPath, projSpec)
+		span.End(err)
 	}
 }
 
-// verifyGroupsDeletion 验证组是否已删除
-func (p *ResourceProcessor) verifyGroupsDeletion(groups []types.GroupSpec, maxRetries int, retryInterval time.Duration) bool {
-	log.Printf("  等待 GitLab 处理组删除...\n")
+// deleteProject 删除单个项目，结果记录到 Results；项目不存在时不做任何操作
+func (p *ResourceProcessor) deleteProject(ctx context.Context, username, fullPath string, projSpec types.ProjectSpec) error {
+	started := time.Now()
+	project, err := p.Client.GetProject(ctx, fullPath)
+	if err != nil {
+		p.logger().Error(i18n.T("检查项目失败"), logging.KeyProject, fullPath, logging.Err(err), logging.Duration(started))
+		err = fmt.Errorf(i18n.T("检查项目失败: %w"), err)
+		p.record(ctx, nil, fullPath, events.Event{Action: result.ActionDelete, Kind: result.KindProject, User: username, Name: projSpec.Name, Path: fullPath, Started: started, Err: err})
+		return err
+	}
+	if project == nil {
+		return nil
+	}
 
-	for retry := 1; retry <= maxRetries; retry++ {
-		log.Printf("  验证组删除状态 (尝试 %d/%d)...\n", retry, maxRetries)
-		time.Sleep(retryInterval)
+	p.logger().Info(i18n.T("删除项目"), logging.KeyProject, fullPath, "id", project.ID)
+	err = p.Client.DeleteProject(ctx, project.ID, fullPath)
+	if err != nil {
+		p.logger().Error(i18n.T("删除项目失败"), logging.KeyProject, fullPath, logging.Err(err), logging.Duration(started))
+	} else {
+		p.logger().Info(i18n.T("项目删除成功"), logging.KeyProject, fullPath, logging.Duration(started))
+	}
+	p.record(ctx, nil, fullPath, events.Event{Type: events.TypeDelete, Action: result.ActionDelete, Kind: result.KindProject, User: username, Name: projSpec.Name, ID: project.ID, Path: fullPath, Started: started, Err: err})
+	return err
+}
+
+// verifyGroupsDeletion 轮询直到配置文件中的组都已删除
+// 超时返回 utils.ErrWaitTimeout，被取消时返回 ctx.Err()
+func (p *ResourceProcessor) verifyGroupsDeletion(ctx context.Context, username string, groups []types.GroupSpec) error {
+	p.logger().Info(i18n.T("等待 GitLab 处理组删除"))
+
+	started := time.Now()
+	ctx, span := tracing.Start(ctx, "verify", slog.String("kind", string(result.KindGroup)))
+	err := utils.PollUntil(ctx, p.pollOptions(), func(ctx context.Context, attempt int) (bool, error) {
+		p.logger().Debug(i18n.T("验证组删除状态"), "attempt", attempt)
 
 		remainingGroups := 0
 		for _, groupSpec := range groups {
-			verifyGroup, _ := p.Client.GetGroup(groupSpec.Path)
+			// 只有确认不存在（404）才算已删除；请求失败时该组按仍存在处理，下一轮再检查
+			verifyGroup, err := p.Client.GetGroup(ctx, groupSpec.Path)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return false, ctxErr
+				}
+				p.logger().Warn(i18n.T("检查组失败"), logging.KeyGroup, groupSpec.Path, "attempt", attempt, logging.Err(err))
+				remainingGroups++
+				continue
+			}
 			if verifyGroup != nil {
 				remainingGroups++
-				log.Printf("    ⚠ 组 '%s' 仍然存在\n", groupSpec.Name)
+				p.logger().Debug(i18n.T("组仍然存在"), logging.KeyGroup, groupSpec.Path)
 			}
 		}
 
-		if remainingGroups == 0 {
-			log.Printf("  ✓ 验证通过: 配置文件中的组已彻底删除\n")
-			return true
+		if remainingGroups > 0 {
+			p.logger().Info(i18n.T("组未完全删除，继续等待"), "remaining", remainingGroups, "attempt", attempt)
+			return false, nil
 		}
-
-		log.Printf("  还有 %d 个组未完全删除，继续等待...\n", remainingGroups)
+		return true, nil
+	})
+	span.End(err)
+	p.recordVerify(ctx, result.KindGroup, username, started, err)
+	if err != nil {
+		return err
 	}
 
-	return false
+	p.logger().Info(i18n.T("验证通过: 配置文件中的组已彻底删除"), logging.Duration(started))
+	return nil
 }
 
 // deleteUserOwnedGroups 删除用户拥有的所有其他组
-func (p *ResourceProcessor) deleteUserOwnedGroups(username string) {
-	log.Printf("  检查用户是否还拥有其他组...\n")
+// 只有在被取消时才返回错误，其余失败记录到 Results
+func (p *ResourceProcessor) deleteUserOwnedGroups(ctx context.Context, username string) error {
+	p.logger().Info(i18n.T("检查用户是否还拥有其他组"), "username", username)
 
-	userGroups, err := p.Client.ListUserGroups(username)
+	started := time.Now()
+	userGroups, err := p.Client.ListUserGroups(ctx, username)
 	if err != nil {
-		log.Printf("  ⚠ 获取用户组列表失败: %v\n", err)
-		return
+		p.logger().Error(i18n.T("获取用户组列表失败"), "username", username, logging.Err(err), logging.Duration(started))
+		if ctx.Err() == nil {
+			err = fmt.Errorf(i18n.T("获取用户组列表失败: %w"), err)
+			p.record(ctx, nil, username+"/*", events.Event{Action: result.ActionDelete, Kind: result.KindGroup, User: username, Path: username + "/*", Started: started, Err: err})
+		}
+		return ctx.Err()
 	}
 
 	if len(userGroups) == 0 {
-		log.Printf("  ✓ 用户没有拥有其他组\n")
-		return
+		p.logger().Info(i18n.T("用户没有拥有其他组"), "username", username)
+		return nil
 	}
 
-	log.Printf("  发现用户还拥有 %d 个组，开始删除...\n", len(userGroups))
+	p.logger().Info(i18n.T("删除用户拥有的其他组"), "username", username, "count", len(userGroups))
 	for _, group := range userGroups {
-		log.Printf("    删除组: %s (ID: %d)\n", group.FullPath, group.ID)
-		if err := p.Client.DeleteGroup(group.ID); err != nil {
-			log.Printf("    ⚠ 删除组失败: %v\n", err)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p.logger().Info(i18n.T("删除组"), logging.KeyGroup, group.FullPath, "id", group.ID)
+		started := time.Now()
+		groupCtx, span := tracing.Start(ctx, "group", slog.String(logging.KeyGroup, group.FullPath))
+		err := p.Client.DeleteGroup(groupCtx, group.ID, group.FullPath)
+		if err != nil {
+			p.logger().Error(i18n.T("删除组失败"), logging.KeyGroup, group.FullPath, logging.Err(err), logging.Duration(started))
 		} else {
-			log.Printf("    ✓ 组删除成功\n")
+			p.logger().Info(i18n.T("组删除成功"), logging.KeyGroup, group.FullPath, logging.Duration(started))
 		}
+		p.record(ctx, span, group.FullPath, events.Event{Type: events.TypeDelete, Action: result.ActionDelete, Kind: result.KindGroup, User: username, Name: group.Name, ID: group.ID, Path: group.FullPath, Started: started, Err: err})
 	}
 
 	// 验证所有组已删除
-	p.verifyUserGroupsDeletion(username, 10, 5*time.Second)
-}
-
-// verifyUserGroupsDeletion 验证用户的所有组是否已删除
-func (p *ResourceProcessor) verifyUserGroupsDeletion(username string, maxRetries int, retryInterval time.Duration) {
-	log.Printf("  等待用户所有组删除完成...\n")
+	p.logger().Info(i18n.T("等待用户所有组删除完成"), "username", username)
+	started = time.Now()
+	err = p.waitForUserGroupsGone(ctx, username)
+	p.recordVerify(ctx, result.KindGroup, username, started, err)
+	if err != nil {
+		if !errors.Is(err, utils.ErrWaitTimeout) {
+			return err
+		}
+		p.logger().Warn(i18n.T("用户的部分组可能仍然存在"), "username", username, logging.Duration(started))
+		return nil
+	}
 
-	for retry := 1; retry <= maxRetries; retry++ {
-		time.Sleep(retryInterval)
-		log.Printf("  验证所有组删除状态 (尝试 %d/%d)...\n", retry, maxRetries)
+	p.logger().Info(i18n.T("验证通过: 用户所有组已彻底删除"), "username", username, logging.Duration(started))
+	return nil
+}
 
-		remainingUserGroups, _ := p.Client.ListUserGroups(username)
+// waitForUserGroupsGone 轮询直到用户不再拥有任何组
+func (p *ResourceProcessor) waitForUserGroupsGone(ctx context.Context, username string) error {
+	ctx, span := tracing.Start(ctx, "verify", slog.String("kind", string(result.KindGroup)))
+	err := utils.PollUntil(ctx, p.pollOptions(), func(ctx context.Context, attempt int) (bool, error) {
+		remainingUserGroups, err := p.Client.ListUserGroups(ctx, username)
+		if err != nil {
+			p.logger().Warn(i18n.T("获取用户组列表失败"), "username", username, "attempt", attempt, logging.Err(err))
+			return false, nil
+		}
 		if len(remainingUserGroups) == 0 {
-			log.Printf("  ✓ 验证通过: 用户所有组已彻底删除\n")
-			return
+			return true, nil
 		}
 
-		log.Printf("  还有 %d 个组未完全删除，继续等待...\n", len(remainingUserGroups))
+		p.logger().Info(i18n.T("组未完全删除，继续等待"), "username", username, "remaining", len(remainingUserGroups), "attempt", attempt)
 		for _, g := range remainingUserGroups {
-			log.Printf("    - %s (ID: %d)\n", g.FullPath, g.ID)
+			p.logger().Debug(i18n.T("组仍然存在"), logging.KeyGroup, g.FullPath, "id", g.ID)
 		}
+		return false, nil
+	})
+	span.End(err)
+	return err
+}
+
+// waitForNamespaceSync 在删除用户之前等待 GitLab 完成组删除的内部数据同步
+// GitLab 报告用户不再拥有任何组后立即返回；超时只记录警告
+func (p *ResourceProcessor) waitForNamespaceSync(ctx context.Context, username string) error {
+	p.logger().Info(i18n.T("等待 GitLab 内部数据同步"), "username", username)
+	if err := p.waitForUserGroupsGone(ctx, username); err != nil {
+		if !errors.Is(err, utils.ErrWaitTimeout) {
+			return err
+		}
+		p.logger().Warn(i18n.T("等待数据同步超时，继续删除用户"), "username", username)
 	}
+	return nil
 }
 
 // deleteUser 删除用户并验证
-func (p *ResourceProcessor) deleteUser(userID int, username string) error {
-	log.Printf("  删除用户: %s\n", username)
-	if err := p.Client.DeleteUser(userID); err != nil {
+func (p *ResourceProcessor) deleteUser(ctx context.Context, userID int, username string) error {
+	p.logger().Info(i18n.T("删除用户"), "username", username, "id", userID)
+	started := time.Now()
+	err := p.Client.DeleteUser(ctx, userID, username)
+	if err != nil {
+		err = &result.ResourceError{Kind: result.KindUser, Name: username, Action: result.ActionDelete, Err: err}
+	}
+	p.record(ctx, nil, username, events.Event{Type: events.TypeDelete, Action: result.ActionDelete, Kind: result.KindUser, User: username, ID: userID, Started: started, Err: err})
+	if err != nil {
 		return err
 	}
 
-	log.Printf("  ✓ 用户删除成功\n")
-	log.Printf("  等待 GitLab 完成删除操作 (10秒)...\n")
-	time.Sleep(10 * time.Second)
+	p.logger().Info(i18n.T("用户删除成功"), "username", username, logging.Duration(started))
+	p.logger().Info(i18n.T("等待 GitLab 完成删除操作"), "username", username)
 
 	// 验证删除
-	verifyUser, _ := p.Client.GetUser(username)
-	if verifyUser == nil {
-		log.Printf("  ✓ 验证通过: 用户已彻底删除\n\n")
-	} else {
-		log.Printf("  ⚠ 验证失败: 用户可能仍然存在\n\n")
+	started = time.Now()
+	verifyCtx, span := tracing.Start(ctx, "verify", slog.String("kind", string(result.KindUser)))
+	err = utils.PollUntil(verifyCtx, p.pollOptions(), func(ctx context.Context, attempt int) (bool, error) {
+		verifyUser, err := p.Client.GetUser(ctx, username)
+		if err != nil {
+			p.logger().Warn(i18n.T("检查用户失败"), "username", username, "attempt", attempt, logging.Err(err))
+			return false, nil
+		}
+		return verifyUser == nil, nil
+	})
+	span.End(err)
+	p.recordVerify(ctx, result.KindUser, username, started, err)
+	switch {
+	case err == nil:
+		p.logger().Info(i18n.T("验证通过: 用户已彻底删除"), "username", username, logging.Duration(started))
+	case errors.Is(err, utils.ErrWaitTimeout):
+		p.logger().Warn(i18n.T("验证失败: 用户可能仍然存在"), "username", username, logging.Duration(started))
+	default:
+		return err
 	}
 
 	return nil
 }
 
+// record 把一个资源操作的结果分发到汇总、事件流、指标和 trace。e 是该操作的事件：e.Err 不为空时
+// 记为失败并把事件类型改为 error；e.Type 为 exists 时记为已存在而跳过；否则记为成功。
+// name 是汇总中的资源名称；span 不为 nil 时以 e.Err 结束
+func (p *ResourceProcessor) record(ctx context.Context, span *tracing.Span, name string, e events.Event) {
+	span.End(e.Err)
+	status := result.StatusSucceeded
+	switch {
+	case e.Err != nil:
+		status, e.Type = result.StatusFailed, events.TypeError
+		p.Results.Failed(e.Kind, name, e.Action, e.Started, e.Err)
+	case e.Type == events.TypeExists:
+		status = result.StatusSkipped
+		p.Results.Skipped(e.Kind, name, e.Action, i18n.T("已存在"))
+	default:
+		p.Results.Succeeded(e.Kind, name, e.Action, e.Started)
+	}
+	metrics.FromContext(ctx).ObserveResource(e.Kind, e.Action, status, time.Since(e.Started))
+	p.Events.Emit(e)
+}
+
+// createdOrExists 返回创建操作成功时的事件类型，existed 表示资源在此之前已存在
+func createdOrExists(existed bool) events.Type {
+	if existed {
+		return events.TypeExists
+	}
+	return events.TypeCreate
+}
+
+// recordVerify 在等待 GitLab 完成异步删除后统计等待时间并输出 verify 事件，超时时事件带有错误；
+// 被取消时不记录
+func (p *ResourceProcessor) recordVerify(ctx context.Context, kind result.Kind, username string, started time.Time, err error) {
+	if err != nil && !errors.Is(err, utils.ErrWaitTimeout) {
+		return
+	}
+	metrics.FromContext(ctx).ObserveWait(kind, time.Since(started), err != nil)
+	p.Events.Emit(events.Event{Type: events.TypeVerify, Action: result.ActionDelete, Kind: kind, User: username, Started: started, Err: err})
+}
+
 // ========================================
 // 用户删除流程（根据用户名）
 // ========================================
 
 // ProcessUserDelete 根据用户名删除用户及其所有资源
-func (p *ResourceProcessor) ProcessUserDelete(username string) error {
-	user, err := p.Client.GetUser(username)
+func (p *ResourceProcessor) ProcessUserDelete(ctx context.Context, username string) error {
+	ctx, span := tracing.Start(ctx, "user", slog.String(logging.KeyUser, username), slog.String(logging.KeyAction, string(result.ActionDelete)))
+	err := p.processUserDelete(ctx, username)
+	span.End(err)
+	return err
+}
+
+func (p *ResourceProcessor) processUserDelete(ctx context.Context, username string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.Events.Emit(events.Event{Type: events.TypeStart, Action: result.ActionDelete, Kind: result.KindUser, User: username})
+
+	started := time.Now()
+	user, err := p.Client.GetUser(ctx, username)
 	if err != nil {
-		log.Printf("  ⚠ 检查用户失败: %v\n", err)
-		return nil
+		p.logger().Error(i18n.T("检查用户失败"), "username", username, logging.Err(err), logging.Duration(started))
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		err = &result.ResourceError{Kind: result.KindUser, Name: username, Action: result.ActionDelete, Err: fmt.Errorf(i18n.T("检查用户失败: %w"), err)}
+		p.record(ctx, nil, username, events.Event{Action: result.ActionDelete, Kind: result.KindUser, User: username, Started: started, Err: err})
+		return err
 	}
 
 	if user == nil {
-		log.Printf("  用户不存在，跳过: %s\n\n", username)
+		p.logger().Info(i18n.T("用户不存在，跳过"), "username", username)
+		p.Results.Skipped(result.KindUser, username, result.ActionDelete, i18n.T("不存在"))
 		return nil
 	}
 
-	log.Printf("  找到用户 '%s' (ID: %d, 邮箱: %s)\n", user.Username, user.ID, user.Email)
+	p.logger().Info(i18n.T("找到用户"), "username", user.Username, "id", user.ID, "email", user.Email)
 
 	// 1. 删除用户级项目（不属于任何组的项目）
-	log.Printf("  删除用户级项目...\n")
-	p.deleteUserProjects(username)
+	p.logger().Info(i18n.T("删除用户级项目"))
+	p.deleteUserProjects(ctx, username)
 
 	// 2. 删除用户拥有的所有组
-	p.deleteUserOwnedGroups(username)
+	if err := p.deleteUserOwnedGroups(ctx, username); err != nil {
+		return err
+	}
 
 	// 3. 等待数据同步
-	log.Printf("  等待 GitLab 内部数据同步 (10秒)...\n")
-	time.Sleep(10 * time.Second)
+	if err := p.waitForNamespaceSync(ctx, username); err != nil {
+		return err
+	}
 
 	// 4. 删除用户
-	if err := p.deleteUser(user.ID, username); err != nil {
-		log.Printf("  ⚠ 删除用户失败: %v\n\n", err)
+	if err := p.deleteUser(ctx, user.ID, username); err != nil {
+		p.logger().Error(i18n.T("删除用户失败"), logging.Err(err))
 		return err
 	}
 
@@ -0,0 +1,218 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"gitlab-cli-sdk/internal/events"
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/logging"
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/internal/result"
+	"gitlab-cli-sdk/internal/tracing"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// ========================================
+// 输出结果校验流程
+// ========================================
+
+// ProcessUserVerify 检查输出结果中记录的用户及其 Token、组和项目是否仍与 GitLab 一致。
+// 每个资源的检查结果记录到 Results：与记录不一致（漂移）或无法检查的资源记为失败，原因列出差异；
+// 用户不存在时其余资源记为跳过。只有被取消时返回错误
+func (p *ResourceProcessor) ProcessUserVerify(ctx context.Context, output types.UserOutput) error {
+	ctx, span := tracing.Start(ctx, "user", slog.String(logging.KeyUser, output.Username), slog.String(logging.KeyAction, string(result.ActionVerify)))
+	err := p.processUserVerify(ctx, output)
+	span.End(err)
+	return err
+}
+
+func (p *ResourceProcessor) processUserVerify(ctx context.Context, output types.UserOutput) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	started := time.Now()
+	user, err := p.Client.GetUser(ctx, output.Username)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		p.recordVerified(ctx, nil, result.KindUser, output.Username, started, checkFailed(err))
+		p.skipVerify(output, i18n.T("用户检查失败"))
+		return nil
+	}
+	p.recordVerified(ctx, nil, result.KindUser, output.Username, started, userDrift(output, user))
+	if user == nil {
+		p.skipVerify(output, i18n.T("用户不存在"))
+		return nil
+	}
+
+	if output.Token != nil {
+		p.verifyToken(ctx, output.Username, *output.Token, user.ID)
+	}
+	for _, group := range output.Groups {
+		p.verifyGroup(ctx, group)
+		for _, project := range group.Projects {
+			p.verifyProject(ctx, project)
+		}
+	}
+	for _, project := range output.Projects {
+		p.verifyProject(ctx, project)
+	}
+	return ctx.Err()
+}
+
+// verifyToken 用 Token 本身请求 self 接口，检查它能否认证、属于该用户并带有记录的权限范围
+func (p *ResourceProcessor) verifyToken(ctx context.Context, username string, recorded types.TokenOutput, userID int) {
+	// --redact 写出的输出结果中 Token 是 ${<USERNAME>_TOKEN} 形式的引用，从环境变量读取
+	value := os.ExpandEnv(recorded.Value)
+	if value == "" {
+		p.Results.Skipped(result.KindToken, username, result.ActionVerify, i18n.T("输出结果中没有 Token 值"))
+		return
+	}
+	redact.Add(value)
+
+	tokenCtx, span := tracing.Start(ctx, "token", slog.String(logging.KeyUser, username))
+	started := time.Now()
+	token, err := p.Client.GetTokenSelf(tokenCtx, value)
+	if err != nil {
+		p.recordVerified(ctx, span, result.KindToken, username, started, checkFailed(err))
+		return
+	}
+	p.recordVerified(ctx, span, result.KindToken, username, started, tokenDrift(recorded, userID, token))
+}
+
+// verifyGroup 检查组仍然存在，并且 ID 和可见性与记录一致
+func (p *ResourceProcessor) verifyGroup(ctx context.Context, recorded types.GroupOutput) {
+	groupCtx, span := tracing.Start(ctx, "group", slog.String(logging.KeyGroup, recorded.Path))
+	started := time.Now()
+	group, err := p.Client.GetGroup(groupCtx, recorded.Path)
+	if err != nil {
+		p.recordVerified(ctx, span, result.KindGroup, recorded.Path, started, checkFailed(err))
+		return
+	}
+	p.recordVerified(ctx, span, result.KindGroup, recorded.Path, started, groupDrift(recorded, group))
+}
+
+// verifyProject 检查项目仍然存在，并且 ID 和可见性与记录一致
+func (p *ResourceProcessor) verifyProject(ctx context.Context, recorded types.ProjectOutput) {
+	projectCtx, span := tracing.Start(ctx, "project", slog.String(logging.KeyProject, recorded.Path))
+	started := time.Now()
+	project, err := p.Client.GetProject(projectCtx, recorded.Path)
+	if err != nil {
+		p.recordVerified(ctx, span, result.KindProject, recorded.Path, started, checkFailed(err))
+		return
+	}
+	p.recordVerified(ctx, span, result.KindProject, recorded.Path, started, projectDrift(recorded, project))
+}
+
+// skipVerify 在无法检查用户时把该用户的 Token、组和项目记录为跳过
+func (p *ResourceProcessor) skipVerify(output types.UserOutput, reason string) {
+	if output.Token != nil {
+		p.Results.Skipped(result.KindToken, output.Username, result.ActionVerify, reason)
+	}
+	for _, group := range output.Groups {
+		p.Results.Skipped(result.KindGroup, group.Path, result.ActionVerify, reason)
+		for _, project := range group.Projects {
+			p.Results.Skipped(result.KindProject, project.Path, result.ActionVerify, reason)
+		}
+	}
+	for _, project := range output.Projects {
+		p.Results.Skipped(result.KindProject, project.Path, result.ActionVerify, reason)
+	}
+}
+
+// recordVerified 记录一个资源的检查结果，drift 为空表示与记录一致
+func (p *ResourceProcessor) recordVerified(ctx context.Context, span *tracing.Span, kind result.Kind, name string, started time.Time, drift []string) {
+	var err error
+	if len(drift) == 0 {
+		p.logger().Info(i18n.T("资源与记录一致"), "kind", kind, "name", name, logging.Duration(started))
+	} else {
+		err = &result.ResourceError{Kind: kind, Name: name, Action: result.ActionVerify, Err: errors.New(strings.Join(drift, "; "))}
+		p.logger().Warn(i18n.T("资源与记录不一致"), "kind", kind, "name", name, "drift", strings.Join(drift, "; "), logging.Duration(started))
+	}
+	p.record(ctx, span, name, events.Event{Type: events.TypeVerify, Action: result.ActionVerify, Kind: kind, Name: name, Started: started, Err: err})
+}
+
+// checkFailed 把无法完成检查的 API 错误作为检查结果
+func checkFailed(err error) []string {
+	return []string{i18n.Tf("检查失败: %v", err)}
+}
+
+// userDrift 返回用户与记录的差异；user 为 nil 表示用户不存在
+func userDrift(recorded types.UserOutput, user *gitlab.User) []string {
+	if user == nil {
+		return []string{i18n.T("不存在")}
+	}
+	var drift []string
+	if recorded.UserID != 0 && user.ID != recorded.UserID {
+		drift = append(drift, i18n.Tf("ID 为 %d，记录为 %d", user.ID, recorded.UserID))
+	}
+	if user.State != "active" {
+		drift = append(drift, i18n.Tf("状态为 %s", user.State))
+	}
+	return drift
+}
+
+// tokenDrift 返回 self 接口返回的 Token 与记录的差异；token 为 nil 表示无法认证
+func tokenDrift(recorded types.TokenOutput, userID int, token *gitlab.PersonalAccessToken) []string {
+	if token == nil {
+		return []string{i18n.T("无法认证（无效、已撤销或已过期）")}
+	}
+	var drift []string
+	if !token.Active || token.Revoked {
+		drift = append(drift, i18n.T("已失效"))
+	}
+	if token.UserID != userID {
+		drift = append(drift, i18n.Tf("属于用户 ID %d，应为 %d", token.UserID, userID))
+	}
+	var missing []string
+	for _, scope := range recorded.Scope {
+		if !slices.Contains(token.Scopes, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		drift = append(drift, i18n.Tf("缺少权限范围 %s（实际为 %s）", strings.Join(missing, ", "), strings.Join(token.Scopes, ", ")))
+	}
+	if recorded.ExpiresAt != "" && token.ExpiresAt != nil && token.ExpiresAt.String() != recorded.ExpiresAt {
+		drift = append(drift, i18n.Tf("过期时间为 %s，记录为 %s", token.ExpiresAt.String(), recorded.ExpiresAt))
+	}
+	return drift
+}
+
+// groupDrift 返回组与记录的差异；group 为 nil 表示组不存在
+func groupDrift(recorded types.GroupOutput, group *gitlab.Group) []string {
+	if group == nil {
+		return []string{i18n.T("不存在")}
+	}
+	return namespaceDrift(recorded.GroupID, group.ID, recorded.Visibility, string(group.Visibility))
+}
+
+// projectDrift 返回项目与记录的差异；project 为 nil 表示项目不存在
+func projectDrift(recorded types.ProjectOutput, project *gitlab.Project) []string {
+	if project == nil {
+		return []string{i18n.T("不存在")}
+	}
+	return namespaceDrift(recorded.ProjectID, project.ID, recorded.Visibility, string(project.Visibility))
+}
+
+// namespaceDrift 比较组或项目的 ID 和可见性，记录中缺少的字段不比较
+func namespaceDrift(recordedID, id int, recordedVisibility, visibility string) []string {
+	var drift []string
+	if recordedID != 0 && id != recordedID {
+		drift = append(drift, i18n.Tf("ID 为 %d，记录为 %d", id, recordedID))
+	}
+	if recordedVisibility != "" && visibility != recordedVisibility {
+		drift = append(drift, i18n.Tf("可见性为 %s，记录为 %s", visibility, recordedVisibility))
+	}
+	return drift
+}
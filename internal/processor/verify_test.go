@@ -0,0 +1,50 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/pkg/types"
+)
+
+func TestDrift(t *testing.T) {
+	if err := i18n.SetLang("en"); err != nil {
+		t.Fatal(err)
+	}
+	expires := gitlab.ISOTime(time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+	token := &gitlab.PersonalAccessToken{UserID: 7, Active: true, Scopes: []string{"api"}, ExpiresAt: &expires}
+
+	tests := []struct {
+		name  string
+		drift []string
+		want  []string
+	}{
+		{name: "user matches", drift: userDrift(types.UserOutput{UserID: 7}, &gitlab.User{ID: 7, State: "active"})},
+		{name: "user missing", drift: userDrift(types.UserOutput{UserID: 7}, nil), want: []string{"does not exist"}},
+		{name: "user recreated and blocked", drift: userDrift(types.UserOutput{UserID: 7}, &gitlab.User{ID: 9, State: "blocked"}),
+			want: []string{"ID is 9, recorded 7", "state is blocked"}},
+		{name: "token matches", drift: tokenDrift(types.TokenOutput{Scope: []string{"api"}, ExpiresAt: "2026-10-20"}, 7, token)},
+		{name: "token rejected", drift: tokenDrift(types.TokenOutput{}, 7, nil), want: []string{"cannot authenticate"}},
+		{name: "token scopes and expiry", drift: tokenDrift(types.TokenOutput{Scope: []string{"api", "write_repository"}, ExpiresAt: "2026-10-21"}, 8, token),
+			want: []string{"belongs to user ID 7, want 8", "missing scopes write_repository (has api)", "expires at 2026-10-20, recorded 2026-10-21"}},
+		{name: "group visibility", drift: groupDrift(types.GroupOutput{GroupID: 3, Visibility: "private"}, &gitlab.Group{ID: 3, Visibility: gitlab.PublicVisibility}),
+			want: []string{"visibility is public, recorded private"}},
+		{name: "project without recorded fields", drift: projectDrift(types.ProjectOutput{}, &gitlab.Project{ID: 5, Visibility: gitlab.InternalVisibility})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.drift) != len(tt.want) {
+				t.Fatalf("drift = %q, want %q", tt.drift, tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(tt.drift[i], want) {
+					t.Errorf("drift[%d] = %q, want prefix %q", i, tt.drift[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package redact 在日志中隐藏已知的密码和 Token，并生成不含凭证的输出结果。
+//
+// 创建或读取凭证的代码通过 Add 登记凭证值。logging 包配置的 slog 处理器在 ReplaceAttr 中用
+// String 隐藏日志消息和字段的原始值，此时值尚未被 text 或 json 格式转义；日志和汇总表的输出
+// 另外包装为 NewWriter，作为其余写出内容的兜底（--show-secrets 时都不隐藏）。已登记的凭证被替换为 Mask。
+package redact
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// Mask 替换日志中出现的凭证
+const Mask = "******"
+
+// minSecretLength 是登记凭证的最小长度，过短的值会误伤普通文本
+const minSecretLength = 6
+
+var (
+	mu       sync.RWMutex
+	secrets  = make(map[string]bool)
+	replacer = strings.NewReplacer()
+)
+
+// Add 登记需要在日志中隐藏的凭证，空值和过短的值会被忽略
+func Add(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	changed := false
+	for _, value := range values {
+		if len(value) >= minSecretLength && !secrets[value] {
+			secrets[value] = true
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	// 先替换较长的值，避免一个凭证是另一个凭证的一部分时只隐藏一半
+	sorted := make([]string, 0, len(secrets))
+	for value := range secrets {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	pairs := make([]string, 0, 2*len(sorted))
+	for _, value := range sorted {
+		pairs = append(pairs, value, Mask)
+	}
+	replacer = strings.NewReplacer(pairs...)
+}
+
+// AddOutput 登记输出结果中的所有密码和 Token
+func AddOutput(output *types.OutputConfig) {
+	if output == nil {
+		return
+	}
+	for _, user := range output.Users {
+		Add(user.Password)
+		if user.Token != nil {
+			Add(user.Token.Value)
+		}
+	}
+}
+
+// String 返回隐藏了所有已登记凭证的 s
+func String(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return replacer.Replace(s)
+}
+
+// writer masks registered secrets in everything written to w.
+type writer struct {
+	w io.Writer
+}
+
+// NewWriter 返回隐藏已登记凭证的 io.Writer。log 每条日志只调用一次 Write，
+// 因此凭证不会被拆分到两次写入中
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+func (r *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, String(string(p))); err != nil {
+		return 0, err
+	}
+	// 调用方关心的是 p 是否全部处理，而不是替换后的长度
+	return len(p), nil
+}
+
+var envNameInvalid = regexp.MustCompile(`[^A-Z0-9_]+`)
+
+// EnvPrefix 把名称转换为环境变量前缀，例如 ci-bot -> CI_BOT
+func EnvPrefix(name string) string {
+	prefix := envNameInvalid.ReplaceAllString(strings.ToUpper(name), "_")
+	if prefix == "" || (prefix[0] >= '0' && prefix[0] <= '9') {
+		prefix = "USER_" + prefix
+	}
+	return prefix
+}
+
+// UserPrefix 返回用户的环境变量前缀。前缀取自配置文件中的用户名（LogicalName），
+// 不含运行 ID，因此每次运行都相同；没有 LogicalName 时使用生成的用户名。
+// dotenv 输出格式和 Reference 使用同一前缀，使引用可以由 dotenv 文件解析
+func UserPrefix(user types.UserOutput) string {
+	if user.LogicalName != "" {
+		return EnvPrefix(user.LogicalName)
+	}
+	return EnvPrefix(user.Username)
+}
+
+// Reference 返回用户某个凭证的引用，例如 ${TEKTONCD_TOKEN}，
+// 与 dotenv 输出格式中的变量名一致，可以用 envsubst 等工具替换回真实值
+func Reference(user types.UserOutput, field string) string {
+	return "${" + UserPrefix(user) + "_" + field + "}"
+}
+
+// Output 返回输出结果的副本，其中的密码和 Token 被替换为 Reference，原结果不变
+func Output(output *types.OutputConfig) *types.OutputConfig {
+	redacted := *output
+	redacted.Users = make([]types.UserOutput, len(output.Users))
+	for i, user := range output.Users {
+		if user.Password != "" {
+			user.Password = Reference(user, "PASSWORD")
+		}
+		if user.Token != nil {
+			token := *user.Token
+			token.Value = Reference(user, "TOKEN")
+			user.Token = &token
+		}
+		redacted.Users[i] = user
+	}
+	return &redacted
+}
@@ -0,0 +1,50 @@
+package redact
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+func TestWriterMasksSecrets(t *testing.T) {
+	Add("glpat-secret-token", "glpat-secret", "short")
+
+	var buf bytes.Buffer
+	logger := log.New(NewWriter(&buf), "", 0)
+	logger.Printf("token=%s prefix=%s name=short", "glpat-secret-token", "glpat-secret")
+
+	want := "token=****** prefix=****** name=short\n"
+	if buf.String() != want {
+		t.Errorf("log output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	output := &types.OutputConfig{Users: []types.UserOutput{
+		{Username: "ci-bot-20251030150000123-a1b2", LogicalName: "ci-bot", Password: "Passw0rd!", Token: &types.TokenOutput{Value: "glpat-abc", ExpiresAt: "2030-01-01"}},
+		{Username: "1st"},
+		{Username: "2nd", Password: "secret"},
+	}}
+
+	redacted := Output(output)
+	if got := redacted.Users[0].Password; got != "${CI_BOT_PASSWORD}" {
+		t.Errorf("password = %q, want ${CI_BOT_PASSWORD}", got)
+	}
+	if got := redacted.Users[0].Token.Value; got != "${CI_BOT_TOKEN}" {
+		t.Errorf("token = %q, want ${CI_BOT_TOKEN}", got)
+	}
+	if redacted.Users[0].Token.ExpiresAt != "2030-01-01" {
+		t.Errorf("expires_at changed to %q", redacted.Users[0].Token.ExpiresAt)
+	}
+	if redacted.Users[1].Password != "" || redacted.Users[1].Token != nil {
+		t.Errorf("user without credentials got %+v", redacted.Users[1])
+	}
+	if got := redacted.Users[2].Password; got != "${USER_2ND_PASSWORD}" {
+		t.Errorf("password without logical name = %q, want ${USER_2ND_PASSWORD}", got)
+	}
+	if output.Users[0].Password != "Passw0rd!" || output.Users[0].Token.Value != "glpat-abc" {
+		t.Errorf("Output modified the original: %+v", output.Users[0])
+	}
+}
@@ -0,0 +1,186 @@
+package result
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// RunInfo describes the run a report is written for.
+type RunInfo struct {
+	Name     string        // Name identifies the run, e.g. "gitlab-cli user create"
+	Duration time.Duration // Duration is the wall time of the whole run
+	Err      error         // Err is the error that ended the run before all resources were processed
+}
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the summary as a JUnit XML report: one test suite per user and one test case
+// per resource operation, so CI systems show failed operations as failed tests with the API error
+// as the failure message. A run error is reported as an extra errored test case.
+func (s *Summary) WriteJUnit(w io.Writer, run RunInfo) error {
+	report := junitTestSuites{Name: run.Name, Time: seconds(run.Duration)}
+
+	suiteIndex := make(map[string]int)
+	var suiteTimes []time.Duration
+	for _, entry := range s.Entries {
+		i, ok := suiteIndex[entry.User]
+		if !ok {
+			i = len(report.Suites)
+			suiteIndex[entry.User] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: entry.User})
+			suiteTimes = append(suiteTimes, 0)
+		}
+		suiteTimes[i] += entry.Duration
+		suite := &report.Suites[i]
+
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%s %s %s", entry.Action, entry.Kind, entry.Name),
+			ClassName: fmt.Sprintf("%s.%s", entry.User, entry.Kind),
+			Time:      seconds(entry.Duration),
+		}
+		switch entry.Status {
+		case StatusFailed:
+			testCase.Failure = &junitMessage{Message: firstLine(entry.Reason), Type: string(entry.Action) + " " + string(entry.Kind), Text: entry.Reason}
+			suite.Failures++
+		case StatusSkipped:
+			testCase.Skipped = &junitMessage{Message: entry.Reason}
+			suite.Skipped++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	for i, d := range suiteTimes {
+		report.Suites[i].Time = seconds(d)
+	}
+
+	if run.Err != nil {
+		report.Suites = append(report.Suites, junitTestSuite{
+			Name:   run.Name,
+			Time:   seconds(run.Duration),
+			Tests:  1,
+			Errors: 1,
+			Cases: []junitTestCase{{
+				Name:      "run",
+				ClassName: run.Name,
+				Time:      seconds(run.Duration),
+				Error:     &junitMessage{Message: firstLine(run.Err.Error()), Text: run.Err.Error()},
+			}},
+		})
+	}
+
+	for i := range report.Suites {
+		suite := &report.Suites[i]
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// statusIcons marks each status in the Markdown report.
+var statusIcons = map[Status]string{
+	StatusSucceeded: "✅",
+	StatusFailed:    "❌",
+	StatusSkipped:   "⏭️",
+}
+
+// WriteMarkdown writes the summary as a Markdown heading, the totals and a table of all
+// operations, suitable for a merge-request comment.
+func (s *Summary) WriteMarkdown(w io.Writer, run RunInfo) error {
+	succeeded, failed, skipped := s.Counts()
+	icon := statusIcons[StatusSucceeded]
+	if failed > 0 || run.Err != nil {
+		icon = statusIcons[StatusFailed]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s `%s`\n\n", icon, run.Name)
+	fmt.Fprintf(&b, "**%d** succeeded, **%d** failed, **%d** skipped in %s\n\n", succeeded, failed, skipped, run.Duration.Round(time.Millisecond))
+	if run.Err != nil {
+		fmt.Fprintf(&b, "> **Error:** %s\n\n", markdownCell(run.Err.Error()))
+	}
+	if len(s.Entries) > 0 {
+		b.WriteString("| User | Kind | Name | Action | Status | Duration | Reason |\n")
+		b.WriteString("|------|------|------|--------|--------|----------|--------|\n")
+		for _, entry := range s.Entries {
+			duration := ""
+			if entry.Status != StatusSkipped {
+				duration = entry.Duration.Round(time.Millisecond).String()
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s %s | %s | %s |\n",
+				markdownCell(entry.User), entry.Kind, markdownCell(entry.Name), entry.Action,
+				statusIcons[entry.Status], entry.Status, duration, markdownCell(entry.Reason))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// seconds formats a duration as the seconds value JUnit expects.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// markdownCellEscaper keeps a value inside a single Markdown table cell.
+var markdownCellEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func markdownCell(s string) string {
+	return markdownCellEscaper.Replace(s)
+}
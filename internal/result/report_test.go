@@ -0,0 +1,67 @@
+package result
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReports(t *testing.T) {
+	started := time.Now()
+	alice := NewRecorder("alice")
+	alice.Succeeded(KindUser, "alice", ActionCreate, started)
+	alice.Failed(KindGroup, "team", ActionCreate, started, &ResourceError{Kind: KindGroup, Name: "team", Action: ActionCreate, Err: errors.New("400 Bad Request\npath | has already been taken")})
+	alice.Skipped(KindProject, "team/repo", ActionCreate, "组创建失败")
+	bob := NewRecorder("bob")
+	bob.Succeeded(KindToken, "bob-token", ActionCreate, started)
+	summary := NewSummary(alice, bob)
+	run := RunInfo{Name: "gitlab-cli user create", Duration: 1500 * time.Millisecond}
+
+	var junit strings.Builder
+	if err := summary.WriteJUnit(&junit, run); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal([]byte(junit.String()), &report); err != nil {
+		t.Fatalf("unmarshal %s: %v", junit.String(), err)
+	}
+	if report.Tests != 4 || report.Failures != 1 || report.Skipped != 1 || report.Time != "1.500" || len(report.Suites) != 2 {
+		t.Fatalf("report = %+v, want 4 tests, 1 failure and 1 skipped in 2 suites", report)
+	}
+	failure := report.Suites[0].Cases[1].Failure
+	if failure == nil || failure.Message != "400 Bad Request" || !strings.Contains(failure.Text, "already been taken") {
+		t.Errorf("failure = %+v, want the API error", failure)
+	}
+
+	run.Err = errors.New("401 Unauthorized")
+	junit.Reset()
+	if err := summary.WriteJUnit(&junit, run); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	report = junitTestSuites{}
+	if err := xml.Unmarshal([]byte(junit.String()), &report); err != nil {
+		t.Fatalf("unmarshal %s: %v", junit.String(), err)
+	}
+	if runSuite := report.Suites[len(report.Suites)-1]; runSuite.Errors != 1 || runSuite.Time != "1.500" {
+		t.Errorf("run error suite = %+v, want 1 error in 1.500s", runSuite)
+	}
+
+	var markdown strings.Builder
+	if err := summary.WriteMarkdown(&markdown, run); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	out := markdown.String()
+	for _, want := range []string{
+		"### ❌ `gitlab-cli user create`",
+		"**2** succeeded, **1** failed, **1** skipped in 1.5s",
+		"> **Error:** 401 Unauthorized",
+		`| alice | group | team | create | ❌ failed |`,
+		`400 Bad Request<br>path \| has already been taken |`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown is missing %q:\n%s", want, out)
+		}
+	}
+}
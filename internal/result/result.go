@@ -0,0 +1,223 @@
+package result
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Kind identifies the type of GitLab resource an entry refers to.
+type Kind string
+
+const (
+	KindUser    Kind = "user"
+	KindToken   Kind = "token"
+	KindGroup   Kind = "group"
+	KindProject Kind = "project"
+)
+
+// Action identifies what was attempted on a resource.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionDelete Action = "delete"
+	// ActionVerify checks that a resource recorded in an output file still matches the instance.
+	ActionVerify Action = "verify"
+)
+
+// Status is the outcome of a single resource operation.
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped"
+)
+
+// Outcome classifies a whole run and maps to the process exit code.
+type Outcome int
+
+const (
+	// OutcomeSuccess means no resource operation failed.
+	OutcomeSuccess Outcome = iota
+	// OutcomePartialFailure means some operations failed and some succeeded.
+	OutcomePartialFailure
+	// OutcomeTotalFailure means operations failed and none succeeded.
+	OutcomeTotalFailure
+)
+
+// ResourceError is the typed error returned when an operation on a resource fails.
+type ResourceError struct {
+	Kind   Kind
+	Name   string
+	Action Action
+	Err    error
+}
+
+// Error implements error.
+func (e *ResourceError) Error() string {
+	return fmt.Sprintf("%s %s %s: %v", e.Action, e.Kind, e.Name, e.Err)
+}
+
+// Unwrap returns the underlying API error.
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// Entry records the outcome of one operation on one resource.
+type Entry struct {
+	User     string        // User is the username the resource belongs to
+	Kind     Kind          // Kind is the resource type
+	Name     string        // Name is the resource name or path
+	Action   Action        // Action is the attempted operation
+	Status   Status        // Status is the outcome
+	Reason   string        // Reason explains failures and skips
+	Duration time.Duration // Duration is the time spent on the operation
+}
+
+// Recorder collects entries for one user. It is safe for concurrent use, and a nil
+// *Recorder silently drops entries.
+type Recorder struct {
+	mu      sync.Mutex
+	user    string
+	entries []Entry
+}
+
+// NewRecorder creates a recorder for the resources of user.
+func NewRecorder(user string) *Recorder {
+	return &Recorder{user: user}
+}
+
+// Succeeded records a successful operation.
+func (r *Recorder) Succeeded(kind Kind, name string, action Action, started time.Time) {
+	r.add(Entry{Kind: kind, Name: name, Action: action, Status: StatusSucceeded, Duration: time.Since(started)})
+}
+
+// Failed records a failed operation.
+func (r *Recorder) Failed(kind Kind, name string, action Action, started time.Time, err error) {
+	r.add(Entry{Kind: kind, Name: name, Action: action, Status: StatusFailed, Reason: reason(err), Duration: time.Since(started)})
+}
+
+// Skipped records an operation that was not attempted.
+func (r *Recorder) Skipped(kind Kind, name string, action Action, why string) {
+	r.add(Entry{Kind: kind, Name: name, Action: action, Status: StatusSkipped, Reason: why})
+}
+
+// SetUser changes the username attached to entries recorded from now on, for example once the
+// generated username is known.
+func (r *Recorder) SetUser(user string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.user = user
+}
+
+// HasFailures reports whether any failed operation was recorded.
+func (r *Recorder) HasFailures() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		if entry.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns a copy of the recorded entries.
+func (r *Recorder) Entries() []Entry {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+// add appends an entry stamped with the current user.
+func (r *Recorder) add(entry Entry) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry.User = r.user
+	r.entries = append(r.entries, entry)
+}
+
+// reason converts an error to a single-line reason, unwrapping ResourceError to avoid
+// repeating the kind and name already shown in the entry.
+func reason(err error) string {
+	if err == nil {
+		return ""
+	}
+	if resErr, ok := err.(*ResourceError); ok {
+		return resErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// Summary is the result model of a whole run.
+type Summary struct {
+	Entries []Entry
+}
+
+// NewSummary merges recorders in the given order, which keeps the summary deterministic even
+// when users were processed concurrently.
+func NewSummary(recorders ...*Recorder) *Summary {
+	summary := &Summary{}
+	for _, r := range recorders {
+		summary.Entries = append(summary.Entries, r.Entries()...)
+	}
+	return summary
+}
+
+// Counts returns the number of succeeded, failed and skipped entries.
+func (s *Summary) Counts() (succeeded, failed, skipped int) {
+	for _, entry := range s.Entries {
+		switch entry.Status {
+		case StatusSucceeded:
+			succeeded++
+		case StatusFailed:
+			failed++
+		case StatusSkipped:
+			skipped++
+		}
+	}
+	return succeeded, failed, skipped
+}
+
+// Outcome classifies the run for the exit code.
+func (s *Summary) Outcome() Outcome {
+	succeeded, failed, _ := s.Counts()
+	switch {
+	case failed == 0:
+		return OutcomeSuccess
+	case succeeded == 0:
+		return OutcomeTotalFailure
+	default:
+		return OutcomePartialFailure
+	}
+}
+
+// Print writes the summary table followed by the totals.
+func (s *Summary) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "USER\tKIND\tNAME\tACTION\tSTATUS\tREASON")
+	for _, entry := range s.Entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.User, entry.Kind, entry.Name, entry.Action, entry.Status, entry.Reason)
+	}
+	tw.Flush()
+
+	succeeded, failed, skipped := s.Counts()
+	fmt.Fprintf(w, "succeeded: %d, failed: %d, skipped: %d\n", succeeded, failed, skipped)
+}
@@ -0,0 +1,64 @@
+package result
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummaryOutcome(t *testing.T) {
+	started := time.Now()
+	apiErr := errors.New("500 Internal Server Error")
+
+	ok := NewRecorder("alice")
+	ok.Succeeded(KindUser, "alice", ActionCreate, started)
+	ok.Skipped(KindGroup, "team", ActionCreate, "已存在")
+
+	failed := NewRecorder("bob")
+	failed.Failed(KindUser, "bob", ActionCreate, started, &ResourceError{Kind: KindUser, Name: "bob", Action: ActionCreate, Err: apiErr})
+	failed.Skipped(KindProject, "repo", ActionCreate, "用户创建失败")
+
+	tests := []struct {
+		name      string
+		recorders []*Recorder
+		want      Outcome
+	}{
+		{name: "all succeeded", recorders: []*Recorder{ok}, want: OutcomeSuccess},
+		{name: "partial failure", recorders: []*Recorder{ok, failed}, want: OutcomePartialFailure},
+		{name: "total failure", recorders: []*Recorder{failed}, want: OutcomeTotalFailure},
+		{name: "nothing to do", recorders: nil, want: OutcomeSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSummary(tt.recorders...).Outcome(); got != tt.want {
+				t.Errorf("Outcome() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !failed.HasFailures() || ok.HasFailures() {
+		t.Errorf("HasFailures() mismatch")
+	}
+
+	var buf bytes.Buffer
+	NewSummary(ok, failed).Print(&buf)
+	out := buf.String()
+	// The reason drops the kind and name that the table already shows.
+	if !strings.Contains(out, "500 Internal Server Error") || strings.Contains(out, "create user bob") {
+		t.Errorf("unexpected reason in summary:\n%s", out)
+	}
+	if !strings.Contains(out, "succeeded: 1, failed: 1, skipped: 2") {
+		t.Errorf("unexpected totals in summary:\n%s", out)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Succeeded(KindUser, "alice", ActionCreate, time.Now())
+	r.SetUser("alice")
+	if r.Entries() != nil || r.HasFailures() {
+		t.Errorf("nil recorder should record nothing")
+	}
+}
@@ -0,0 +1,241 @@
+package template
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitlab-cli-sdk/internal/redact"
+	"gitlab-cli-sdk/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// 内置输出格式，无需编写模板即可生成各类客户端直接使用的凭证文件
+const (
+	FormatDotenv         = "dotenv"          // 每个用户一组带前缀的环境变量
+	FormatK8sSecret      = "k8s-secret"      // 每个用户一个 Kubernetes Secret
+	FormatNetrc          = "netrc"           // curl、git 等读取的 ~/.netrc
+	FormatGitCredentials = "git-credentials" // git credential-store 使用的 ~/.git-credentials
+	FormatSSHConfig      = "ssh-config"      // 根据 SSH endpoint 生成的 ~/.ssh/config 片段
+)
+
+// formatRenderer renders the output of a run in one built-in format.
+type formatRenderer func(data *types.OutputConfig) (string, error)
+
+var builtinFormats = map[string]formatRenderer{
+	FormatDotenv:         renderDotenv,
+	FormatK8sSecret:      renderK8sSecret,
+	FormatNetrc:          renderNetrc,
+	FormatGitCredentials: renderGitCredentials,
+	FormatSSHConfig:      renderSSHConfig,
+}
+
+// Formats 返回所有内置输出格式的名称（已排序）
+func Formats() []string {
+	names := make([]string, 0, len(builtinFormats))
+	for name := range builtinFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsFormat 判断 name 是否为内置输出格式
+func IsFormat(name string) bool {
+	_, ok := builtinFormats[name]
+	return ok
+}
+
+// PerHostFormat 判断内置格式是否按主机匹配凭证：netrc 和 git-credentials 中同一主机有多个用户时，
+// 客户端只使用第一条，其余用户的凭证不会生效
+func PerHostFormat(format string) bool {
+	return format == FormatNetrc || format == FormatGitCredentials
+}
+
+// CheckFormat 检查输出格式是否存在，以及数据是否满足该格式的要求，供创建资源前提前报错
+func CheckFormat(format string, hasSSH bool) error {
+	if _, ok := builtinFormats[format]; !ok {
+		return fmt.Errorf("unknown output format %q (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	if format == FormatSSHConfig && !hasSSH {
+		return fmt.Errorf("output format %s requires an SSH endpoint (use --ssh-endpoint or GITLAB_SSH_ENDPOINT)", format)
+	}
+	return nil
+}
+
+// RenderFormat 使用内置格式渲染输出
+func RenderFormat(format string, data *types.OutputConfig) (string, error) {
+	render, ok := builtinFormats[format]
+	if !ok {
+		return "", fmt.Errorf("unknown output format %q (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return render(data)
+}
+
+// secret returns the credential git clients should send for a user: the token when one was
+// created, otherwise the password.
+func secret(user types.UserOutput) string {
+	if user.Token != nil && user.Token.Value != "" {
+		return user.Token.Value
+	}
+	return user.Password
+}
+
+// hostPort returns the URL authority of the GitLab endpoint: the host, bracketed when it is an
+// IPv6 address, with the port when it is not the scheme default.
+func hostPort(data *types.OutputConfig) string {
+	if data.Port == 0 || (data.Scheme == "https" && data.Port == 443) || (data.Scheme == "http" && data.Port == 80) {
+		if strings.Contains(data.Host, ":") {
+			return "[" + data.Host + "]"
+		}
+		return data.Host
+	}
+	return net.JoinHostPort(data.Host, strconv.Itoa(data.Port))
+}
+
+// envQuote quotes a dotenv value so that shells and dotenv loaders read it literally.
+func envQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func renderDotenv(data *types.OutputConfig) (string, error) {
+	var b strings.Builder
+	writeVar := func(name, value string) {
+		fmt.Fprintf(&b, "%s=%s\n", name, envQuote(value))
+	}
+
+	writeVar("GITLAB_ENDPOINT", data.Endpoint)
+	writeVar("GITLAB_HOST", data.Host)
+	if data.SSH != nil {
+		writeVar("GITLAB_SSH_ENDPOINT", data.SSH.Endpoint)
+	}
+	if data.RunID != "" {
+		writeVar("GITLAB_RUN_ID", data.RunID)
+	}
+
+	for _, user := range data.Users {
+		prefix := redact.UserPrefix(user)
+		b.WriteString("\n")
+		writeVar(prefix+"_USERNAME", user.Username)
+		writeVar(prefix+"_EMAIL", user.Email)
+		writeVar(prefix+"_USER_ID", strconv.Itoa(user.UserID))
+		if user.Password != "" {
+			writeVar(prefix+"_PASSWORD", user.Password)
+		}
+		if user.Token != nil {
+			writeVar(prefix+"_TOKEN", user.Token.Value)
+			writeVar(prefix+"_TOKEN_EXPIRES_AT", user.Token.ExpiresAt)
+		}
+	}
+	return b.String(), nil
+}
+
+// k8sSecret is the subset of a Kubernetes Secret manifest written by the k8s-secret format.
+type k8sSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	StringData map[string]string `yaml:"stringData"`
+}
+
+type k8sMetadata struct {
+	Name   string            `yaml:"name"`
+	Labels map[string]string `yaml:"labels"`
+}
+
+var dnsLabelInvalid = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// secretName turns a username into a valid Secret name (RFC 1123 subdomain).
+func secretName(username string) string {
+	name := dnsLabelInvalid.ReplaceAllString(strings.ToLower("gitlab-"+username), "-")
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return strings.Trim(name, "-")
+}
+
+func renderK8sSecret(data *types.OutputConfig) (string, error) {
+	var b strings.Builder
+	for i, user := range data.Users {
+		labels := map[string]string{"app.kubernetes.io/managed-by": "gitlab-cli"}
+		if data.RunID != "" {
+			labels["gitlab-cli/run-id"] = data.RunID
+		}
+		stringData := map[string]string{
+			"endpoint": data.Endpoint,
+			"username": user.Username,
+			"email":    user.Email,
+		}
+		if user.Password != "" {
+			stringData["password"] = user.Password
+		}
+		if user.Token != nil {
+			stringData["token"] = user.Token.Value
+		}
+
+		manifest, err := yaml.Marshal(k8sSecret{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Metadata:   k8sMetadata{Name: secretName(user.Username), Labels: labels},
+			Type:       "Opaque",
+			StringData: stringData,
+		})
+		if err != nil {
+			return "", fmt.Errorf("marshal secret for %s: %w", user.Username, err)
+		}
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		b.Write(manifest)
+	}
+	return b.String(), nil
+}
+
+func renderNetrc(data *types.OutputConfig) (string, error) {
+	var b strings.Builder
+	for _, user := range data.Users {
+		password := secret(user)
+		if password == "" {
+			continue
+		}
+		if strings.ContainsAny(user.Username+password, " \t\n") {
+			return "", fmt.Errorf("netrc: credentials of %s contain whitespace", user.Username)
+		}
+		fmt.Fprintf(&b, "machine %s login %s password %s\n", data.Host, user.Username, password)
+	}
+	return b.String(), nil
+}
+
+func renderGitCredentials(data *types.OutputConfig) (string, error) {
+	var b strings.Builder
+	for _, user := range data.Users {
+		password := secret(user)
+		if password == "" {
+			continue
+		}
+		u := url.URL{Scheme: data.Scheme, User: url.UserPassword(user.Username, password), Host: hostPort(data)}
+		b.WriteString(u.String() + "\n")
+	}
+	return b.String(), nil
+}
+
+func renderSSHConfig(data *types.OutputConfig) (string, error) {
+	if data.SSH == nil {
+		return "", fmt.Errorf("output format %s requires an SSH endpoint", FormatSSHConfig)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Host %s\n", data.SSH.Host)
+	fmt.Fprintf(&b, "  HostName %s\n", data.SSH.Host)
+	if data.SSH.Port != 0 {
+		fmt.Fprintf(&b, "  Port %d\n", data.SSH.Port)
+	}
+	b.WriteString("  User git\n")
+	return b.String(), nil
+}
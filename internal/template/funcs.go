@@ -0,0 +1,209 @@
+package template
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"gitlab-cli-sdk/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Options 控制模板的渲染方式
+type Options struct {
+	// Strict 开启严格模式：访问不存在的 map 键（missingkey=error）或未设置的环境变量时报错，
+	// 而不是输出 <no value> 或空字符串
+	Strict bool
+}
+
+// funcMap 返回模板可用的函数。参数顺序与 Helm/Sprig 一致，便于在管道中使用，
+// 例如 {{ .Token.Value | b64enc | quote }}、{{ toYaml .Groups | nindent 4 }}
+func funcMap(data *types.OutputConfig, opts Options) template.FuncMap {
+	return template.FuncMap{
+		"b64enc":   b64enc,
+		"toYaml":   toYaml,
+		"toJson":   toJSON,
+		"indent":   indent,
+		"nindent":  nindent,
+		"quote":    quote,
+		"default":  defaultValue,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"join":     join,
+		"env":      envFunc(opts.Strict),
+		"now":      time.Now,
+		"date":     date,
+		"required": required,
+		"lookup":   lookupFunc(data),
+	}
+}
+
+// b64enc 返回字符串的标准 base64 编码，例如 Kubernetes Secret 的 data 字段
+func b64enc(v any) string {
+	return base64.StdEncoding.EncodeToString([]byte(toString(v)))
+}
+
+// toYaml 把值编码为 YAML，去掉末尾换行，通常与 nindent 一起使用
+func toYaml(v any) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// toJSON 把值编码为单行 JSON
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// indent 在每一行前添加 spaces 个空格
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// nindent 与 indent 相同，但在开头先换行，便于在 YAML 键后嵌入多行内容
+func nindent(spaces int, s string) string {
+	return "\n" + indent(spaces, s)
+}
+
+// quote 返回按 JSON 规则加双引号并转义的字符串，可安全用作 JSON 字符串或 YAML 双引号字符串。
+// 不转义 <、> 和 &，便于在配置文件中阅读
+func quote(v any) (string, error) {
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(toString(v)); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// defaultValue 在 value 为空（nil、零值、空字符串或空列表）时返回 def
+func defaultValue(def, value any) any {
+	if isEmpty(value) {
+		return def
+	}
+	return value
+}
+
+// join 用 sep 连接列表中的元素
+func join(sep string, list any) (string, error) {
+	v := reflect.ValueOf(list)
+	if !v.IsValid() {
+		return "", nil
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+	items := make([]string, v.Len())
+	for i := range items {
+		items[i] = toString(v.Index(i).Interface())
+	}
+	return strings.Join(items, sep), nil
+}
+
+// envFunc 返回读取环境变量的函数，严格模式下变量未设置时报错
+func envFunc(strict bool) func(name string) (string, error) {
+	return func(name string) (string, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok && strict {
+			return "", fmt.Errorf("env: %s is not set", name)
+		}
+		return value, nil
+	}
+}
+
+// date 按 Go 的时间格式 layout 格式化时间，t 可以是 time.Time 或 YYYY-MM-DD/RFC 3339 字符串，
+// 例如 {{ date "2006-01-02" now }}、{{ date "Jan 2, 2006" .Token.ExpiresAt }}
+func date(layout string, t any) (string, error) {
+	switch t := t.(type) {
+	case time.Time:
+		return t.Format(layout), nil
+	case *time.Time:
+		if t == nil {
+			return "", nil
+		}
+		return t.Format(layout), nil
+	case string:
+		for _, parse := range []string{time.DateOnly, time.RFC3339} {
+			if parsed, err := time.Parse(parse, t); err == nil {
+				return parsed.Format(layout), nil
+			}
+		}
+		return "", fmt.Errorf("date: cannot parse %q as a date", t)
+	}
+	return "", fmt.Errorf("date: expected a time or a date string, got %T", t)
+}
+
+// required 在 value 为空时以 message 报错，用于确保模板依赖的数据存在
+func required(message string, value any) (any, error) {
+	if isEmpty(value) {
+		return nil, fmt.Errorf("%s", message)
+	}
+	return value, nil
+}
+
+// lookupFunc 返回按逻辑名查找用户的函数。逻辑名是配置文件中写的 username，
+// 例如 {{ (lookup "tektoncd").Token.Value }}，找不到时报错
+func lookupFunc(data *types.OutputConfig) func(name string) (*types.UserOutput, error) {
+	return func(name string) (*types.UserOutput, error) {
+		if data != nil {
+			for i := range data.Users {
+				user := &data.Users[i]
+				if user.LogicalName == name || user.Username == name {
+					return user, nil
+				}
+			}
+			// 旧的输出文件没有记录逻辑名，prefix 模式下按运行 ID 推导
+			if data.RunID != "" {
+				for i := range data.Users {
+					if data.Users[i].Username == name+"-"+data.RunID {
+						return &data.Users[i], nil
+					}
+				}
+			}
+		}
+		return nil, fmt.Errorf("lookup: no user named %q", name)
+	}
+}
+
+// isEmpty reports whether v is nil or the zero value of its type, or an empty list or map.
+func isEmpty(v any) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return rv.IsZero()
+}
+
+// toString formats v for text functions; nil becomes the empty string.
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
@@ -6,11 +6,12 @@ import (
 	"os"
 	"text/template"
 
+	"gitlab-cli-sdk/internal/utils"
 	"gitlab-cli-sdk/pkg/types"
 )
 
-// RenderTemplate 使用模板文件渲染输出
-func RenderTemplate(templateFile string, data *types.OutputConfig) (string, error) {
+// RenderTemplate 使用模板文件渲染输出，模板中可以使用 funcMap 中的函数
+func RenderTemplate(templateFile string, data *types.OutputConfig, opts Options) (string, error) {
 	// 读取模板文件
 	templateContent, err := os.ReadFile(templateFile)
 	if err != nil {
@@ -18,7 +19,11 @@ func RenderTemplate(templateFile string, data *types.OutputConfig) (string, erro
 	}
 
 	// 创建模板
-	tmpl, err := template.New("output").Parse(string(templateContent))
+	tmpl := template.New("output").Funcs(funcMap(data, opts))
+	if opts.Strict {
+		tmpl = tmpl.Option("missingkey=error")
+	}
+	tmpl, err = tmpl.Parse(string(templateContent))
 	if err != nil {
 		return "", fmt.Errorf("parse template: %w", err)
 	}
@@ -32,18 +37,15 @@ func RenderTemplate(templateFile string, data *types.OutputConfig) (string, erro
 	return buf.String(), nil
 }
 
-// SaveTemplateOutput 使用模板渲染并保存到文件
-func SaveTemplateOutput(templateFile, outputFile string, data *types.OutputConfig) error {
-	// 渲染模板
-	result, err := RenderTemplate(templateFile, data)
-	if err != nil {
-		return err
-	}
+// WriteOutput 把渲染结果写到只允许当前用户读写的文件，- 表示标准输出
+func WriteOutput(outputFile, content string) error {
+	return writeOutput(outputFile, content, 0600)
+}
 
-	// 保存到文件
-	if err := os.WriteFile(outputFile, []byte(result), 0644); err != nil {
+// writeOutput 把渲染结果以 perm 权限原子地写到文件，- 表示标准输出
+func writeOutput(outputFile, content string, perm os.FileMode) error {
+	if err := utils.WriteFileAtomic(outputFile, []byte(content), perm); err != nil {
 		return fmt.Errorf("write output file: %w", err)
 	}
-
 	return nil
 }
@@ -0,0 +1,79 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab-cli-sdk/pkg/types"
+)
+
+// writeTemplate writes content to a temporary template file and returns its path.
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "template.tpl")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	return path
+}
+
+func TestRenderTemplateFuncs(t *testing.T) {
+	t.Setenv("TEMPLATE_TEST_NAMESPACE", "ci")
+	data := &types.OutputConfig{
+		RunID: "20251030150000123-a1b2",
+		Users: []types.UserOutput{
+			{Username: "bot-20251030150000123-a1b2", LogicalName: "bot", Token: &types.TokenOutput{Value: "glpat-abc", Scope: []string{"api", "read_user"}, ExpiresAt: "2030-01-02"}},
+			{Username: "alice-20251030150000123-a1b2"},
+		},
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: `{{ (lookup "bot").Token.Value | b64enc }}`, want: "Z2xwYXQtYWJj"},
+		{template: `{{ (lookup "alice").Username }}`, want: "alice-20251030150000123-a1b2"},
+		{template: `{{ join "," (lookup "bot").Token.Scope | upper | quote }}`, want: `"API,READ_USER"`},
+		{template: `{{ "a\x01<b>\"" | quote }}`, want: `"a\u0001<b>\""`},
+		{template: "scope:{{ toYaml (lookup \"bot\").Token.Scope | nindent 2 }}", want: "scope:\n  - api\n  - read_user"},
+		{template: `{{ toJson (lookup "bot").Token.Scope }}`, want: `["api","read_user"]`},
+		{template: `{{ (index .Users 1).Password | default "none" }}`, want: "none"},
+		{template: `{{ env "TEMPLATE_TEST_NAMESPACE" | lower }}`, want: "ci"},
+		{template: `{{ date "02/01/2006" (lookup "bot").Token.ExpiresAt }}`, want: "02/01/2030"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			got, err := RenderTemplate(writeTemplate(t, tt.template), data, Options{})
+			if err != nil {
+				t.Fatalf("RenderTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	data := &types.OutputConfig{Users: []types.UserOutput{{Username: "bot"}}}
+
+	tests := []struct {
+		template string
+		opts     Options
+		want     string
+	}{
+		{template: `{{ required "token is required" (index .Users 0).Token }}`, want: "token is required"},
+		{template: `{{ lookup "missing" }}`, want: `no user named "missing"`},
+		{template: `{{ env "TEMPLATE_TEST_UNSET_VARIABLE" }}`, opts: Options{Strict: true}, want: "TEMPLATE_TEST_UNSET_VARIABLE is not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			_, err := RenderTemplate(writeTemplate(t, tt.template), data, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RenderTemplate() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
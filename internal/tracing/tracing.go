@@ -0,0 +1,261 @@
+// Package tracing 记录一次运行中各个操作的 span（运行 → 用户 → 组 → 项目 → API 调用），
+// 用于定位运行变慢的原因。
+//
+// span 通过 context 传递：NewContext 把 Tracer 放入 context 后，Start 创建的 span 自动成为
+// context 中当前 span 的子 span；context 中没有 Tracer 时 Start 返回 nil *Span，所有方法都是空操作。
+// 运行结束后 WriteOTLP 按 OTLP/JSON 格式导出全部 span，不需要 collector。
+package tracing
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"gitlab-cli-sdk/internal/redact"
+)
+
+// ServiceName 是导出的 resource 中的 service.name
+const ServiceName = "gitlab-cli"
+
+// Kind 是 span 类型，取值与 OTLP 的 SpanKind 相同
+type Kind int
+
+const (
+	// KindInternal 表示进程内的操作，例如处理一个用户
+	KindInternal Kind = 1
+	// KindClient 表示发往 GitLab 的 API 调用
+	KindClient Kind = 3
+)
+
+// Tracer 收集一次运行中结束的 span，可在多个 goroutine 中并发使用
+type Tracer struct {
+	mu      sync.Mutex
+	traceID [16]byte
+	spans   []*Span
+}
+
+// New 创建 Tracer，同一个 Tracer 的所有 span 属于同一个 trace
+func New() *Tracer {
+	t := &Tracer{}
+	_, _ = rand.Read(t.traceID[:])
+	return t
+}
+
+// Span 是一个带有开始和结束时间的操作。Span 只应由创建它的 goroutine 修改；nil *Span 的方法都是空操作
+type Span struct {
+	tracer *Tracer
+	id     [8]byte
+	parent [8]byte
+	name   string
+	kind   Kind
+	start  time.Time
+	end    time.Time
+	attrs  []slog.Attr
+	err    string
+}
+
+type contextKey int
+
+const (
+	tracerKey contextKey = iota
+	spanKey
+)
+
+// NewContext 返回带有 Tracer 的 context，之后在该 context 中创建的 span 由 t 收集
+func NewContext(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey, t)
+}
+
+// FromContext 返回 context 中的当前 span，没有时返回 nil
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey).(*Span)
+	return span
+}
+
+// Start 创建 context 中当前 span 的子 span，并返回以新 span 为当前 span 的 context
+func Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, *Span) {
+	return start(ctx, KindInternal, name, attrs)
+}
+
+// StartClient 创建表示 API 调用的子 span
+func StartClient(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, *Span) {
+	return start(ctx, KindClient, name, attrs)
+}
+
+func start(ctx context.Context, kind Kind, name string, attrs []slog.Attr) (context.Context, *Span) {
+	parent := FromContext(ctx)
+	tracer, _ := ctx.Value(tracerKey).(*Tracer)
+	if parent != nil {
+		tracer = parent.tracer
+	}
+	if tracer == nil {
+		return ctx, nil
+	}
+
+	span := &Span{tracer: tracer, name: name, kind: kind, start: time.Now(), attrs: attrs}
+	_, _ = rand.Read(span.id[:])
+	if parent != nil {
+		span.parent = parent.id
+	}
+	return context.WithValue(ctx, spanKey, span), span
+}
+
+// SetAttributes 添加 span 属性
+func (s *Span) SetAttributes(attrs ...slog.Attr) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// End 结束 span，err 不为空时 span 的状态为失败。重复调用只有第一次生效
+func (s *Span) End(err error) {
+	if s == nil || !s.end.IsZero() {
+		return
+	}
+	s.end = time.Now()
+	if err != nil {
+		s.err = redact.String(err.Error())
+	}
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, s)
+	s.tracer.mu.Unlock()
+}
+
+// Name 返回 span 名称
+func (s *Span) Name() string {
+	return s.name
+}
+
+// Duration 返回 span 的耗时
+func (s *Span) Duration() time.Duration {
+	return s.end.Sub(s.start)
+}
+
+// Attributes 返回 span 属性
+func (s *Span) Attributes() []slog.Attr {
+	return s.attrs
+}
+
+// Err 返回失败原因，成功时为空
+func (s *Span) Err() string {
+	return s.err
+}
+
+// Slowest 返回耗时最长的 n 个已结束的 span，不包括根 span（整个运行）
+func (t *Tracer) Slowest(n int) []*Span {
+	t.mu.Lock()
+	var spans []*Span
+	for _, span := range t.spans {
+		if span.parent != [8]byte{} {
+			spans = append(spans, span)
+		}
+	}
+	t.mu.Unlock()
+
+	slices.SortStableFunc(spans, func(a, b *Span) int {
+		return cmp.Compare(b.Duration(), a.Duration())
+	})
+	if len(spans) > n {
+		spans = spans[:n]
+	}
+	return spans
+}
+
+// WriteOTLP 把已结束的 span 以一行 OTLP/JSON（ExportTraceServiceRequest）写入 w，
+// 与 OpenTelemetry collector 的文件导出格式相同
+func (t *Tracer) WriteOTLP(w io.Writer) error {
+	t.mu.Lock()
+	spans := make([]otlpSpan, 0, len(t.spans))
+	for _, span := range t.spans {
+		spans = append(spans, span.otlp(t.traceID))
+	}
+	t.mu.Unlock()
+
+	request := map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{
+				"attributes": []otlpAttr{{Key: "service.name", Value: map[string]any{"stringValue": ServiceName}}},
+			},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "gitlab-cli-sdk/internal/tracing"},
+				"spans": spans,
+			}},
+		}},
+	}
+	line, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
+
+// otlpSpan 是 OTLP/JSON 中的一个 span，64 位整数按 protobuf JSON 映射编码为字符串
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              Kind       `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []otlpAttr `json:"attributes,omitempty"`
+	Status            otlpStatus `json:"status"`
+}
+
+type otlpAttr struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+// otlpStatus 的 Code 为 0（未设置）或 2（失败）
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (s *Span) otlp(traceID [16]byte) otlpSpan {
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(traceID[:]),
+		SpanID:            hex.EncodeToString(s.id[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parent != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parent[:])
+	}
+	for _, attr := range s.attrs {
+		span.Attributes = append(span.Attributes, otlpAttr{Key: attr.Key, Value: otlpValue(attr.Value)})
+	}
+	if s.err != "" {
+		span.Status = otlpStatus{Code: 2, Message: s.err}
+	}
+	return span
+}
+
+// otlpValue 把 slog.Value 转换为 OTLP 的 AnyValue
+func otlpValue(v slog.Value) map[string]any {
+	switch v.Kind() {
+	case slog.KindInt64:
+		return map[string]any{"intValue": strconv.FormatInt(v.Int64(), 10)}
+	case slog.KindUint64:
+		return map[string]any{"intValue": strconv.FormatUint(v.Uint64(), 10)}
+	case slog.KindBool:
+		return map[string]any{"boolValue": v.Bool()}
+	case slog.KindFloat64:
+		return map[string]any{"doubleValue": v.Float64()}
+	default:
+		return map[string]any{"stringValue": v.String()}
+	}
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTracer(t *testing.T) {
+	if _, span := Start(context.Background(), "untraced"); span != nil {
+		t.Fatalf("Start without a tracer = %v, want nil", span)
+	}
+
+	tracer := New()
+	ctx, run := Start(NewContext(context.Background(), tracer), "run")
+	userCtx, user := Start(ctx, "user", slog.String("user", "bot"))
+	_, call := StartClient(userCtx, "CreateUser")
+	time.Sleep(2 * time.Millisecond)
+	call.SetAttributes(slog.Int("http.response.status_code", 500))
+	call.End(errors.New("500 Internal Server Error"))
+	call.End(nil)
+	user.End(nil)
+	run.End(nil)
+
+	slowest := tracer.Slowest(1)
+	if len(slowest) != 1 || slowest[0].Name() != "user" {
+		t.Fatalf("Slowest(1) = %v, want the user span (the run span is excluded)", slowest)
+	}
+
+	var buf bytes.Buffer
+	if err := tracer.WriteOTLP(&buf); err != nil {
+		t.Fatalf("WriteOTLP: %v", err)
+	}
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &request); err != nil {
+		t.Fatalf("unmarshal %s: %v", buf.String(), err)
+	}
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	byName := make(map[string]otlpSpan)
+	for _, span := range spans {
+		byName[span.Name] = span
+		if span.TraceID != spans[0].TraceID {
+			t.Errorf("span %s has trace ID %s, want %s", span.Name, span.TraceID, spans[0].TraceID)
+		}
+	}
+	if byName["run"].ParentSpanID != "" || byName["user"].ParentSpanID != byName["run"].SpanID || byName["CreateUser"].ParentSpanID != byName["user"].SpanID {
+		t.Errorf("span parents are not run → user → CreateUser: %+v", spans)
+	}
+	if got := byName["CreateUser"]; got.Kind != KindClient || got.Status.Code != 2 || got.Attributes[0].Value["intValue"] != "500" {
+		t.Errorf("CreateUser span = %+v, want a failed client span with status code 500", got)
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// StdioPath is the path that stands for standard input or standard output.
+const StdioPath = "-"
+
+// WriteFileAtomic 把 data 写到同目录下的临时文件，设置权限并同步到磁盘后再重命名为 path，
+// 读者只会看到旧文件或完整的新文件。path 为 "-" 时直接写到标准输出。
+// 权限严格使用 perm，不受 umask 影响。
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if path == StdioPath {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// 成功重命名后临时文件已不存在，Remove 只在失败时清理
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ParseFileMode parses an octal permission such as 0600, 600 or 0o600.
+func ParseFileMode(s string) (os.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0o"), "0O")
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid file mode %q (want an octal permission such as 0600)", s)
+	}
+	return os.FileMode(mode), nil
+}
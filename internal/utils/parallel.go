@@ -0,0 +1,56 @@
+package utils
+
+import "sync"
+
+// RunParallel calls fn for every index in [0, n) using at most concurrency goroutines and
+// returns the error of each call at its index, so callers can report results in input order.
+// When stopOnError is set, indexes that have not started yet are skipped after the first failure
+// and their error stays nil.
+func RunParallel(n, concurrency int, stopOnError bool, fn func(i int) error) []error {
+	errs := make([]error, n)
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	var (
+		mu      sync.Mutex
+		next    int
+		stopped bool
+		wg      sync.WaitGroup
+	)
+
+	// claim hands out the next index to a worker, or -1 when there is nothing left to do.
+	claim := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped || next >= n {
+			return -1
+		}
+		i := next
+		next++
+		return i
+	}
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := claim(); i >= 0; i = claim() {
+				if err := fn(i); err != nil {
+					mu.Lock()
+					errs[i] = err
+					if stopOnError {
+						stopped = true
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
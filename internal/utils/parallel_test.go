@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// TestRunParallelBoundsConcurrency verifies that no more than the requested number of
+// workers run at once and that every index is processed exactly once.
+func TestRunParallelBoundsConcurrency(t *testing.T) {
+	var running, peak, calls atomic.Int32
+	seen := make([]atomic.Int32, 20)
+
+	errs := RunParallel(len(seen), 3, false, func(i int) error {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		seen[i].Add(1)
+		calls.Add(1)
+		return nil
+	})
+
+	if len(errs) != len(seen) {
+		t.Fatalf("len(errs) = %d, want %d", len(errs), len(seen))
+	}
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", got)
+	}
+	for i := range seen {
+		if got := seen[i].Load(); got != 1 {
+			t.Errorf("index %d processed %d times, want 1", i, got)
+		}
+	}
+}
+
+// TestRunParallelStopOnError verifies that errors are reported at their index and that
+// no new work starts after a failure when stopOnError is set.
+func TestRunParallelStopOnError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+
+	errs := RunParallel(10, 1, true, func(i int) error {
+		calls.Add(1)
+		if i == 2 {
+			return boom
+		}
+		return nil
+	})
+
+	if !errors.Is(errs[2], boom) {
+		t.Errorf("errs[2] = %v, want %v", errs[2], boom)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+}
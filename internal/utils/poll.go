@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultPollInterval is the first delay between two checks of a polled condition.
+	defaultPollInterval = time.Second
+	// defaultPollMaxInterval caps the exponential backoff between two checks.
+	defaultPollMaxInterval = 15 * time.Second
+)
+
+// ErrWaitTimeout is returned by PollUntil when the condition did not become true in time.
+var ErrWaitTimeout = errors.New("timed out waiting for condition")
+
+// PollOptions controls how PollUntil checks a condition.
+type PollOptions struct {
+	// Interval is the delay before the second check; it doubles after every unsuccessful check.
+	Interval time.Duration
+	// MaxInterval caps the delay between two checks.
+	MaxInterval time.Duration
+	// Timeout bounds the total wait; zero means the wait is only bounded by the context.
+	Timeout time.Duration
+}
+
+// PollUntil checks condition immediately and then with exponential backoff until it reports
+// done, returns an error, the timeout expires (ErrWaitTimeout) or ctx is cancelled (ctx.Err()).
+// attempt is the 1-based number of the current check.
+func PollUntil(ctx context.Context, opts PollOptions, condition func(ctx context.Context, attempt int) (bool, error)) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultPollMaxInterval
+	}
+
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		done, err := condition(waitCtx, attempt)
+		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() != nil {
+				return fmt.Errorf("%w after %s", ErrWaitTimeout, opts.Timeout)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w after %s", ErrWaitTimeout, opts.Timeout)
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
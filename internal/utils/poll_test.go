@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPollUntilReturnsAsSoonAsDone verifies that polling stops on the first successful check.
+func TestPollUntilReturnsAsSoonAsDone(t *testing.T) {
+	calls := 0
+	err := PollUntil(context.Background(), PollOptions{Interval: time.Millisecond}, func(ctx context.Context, attempt int) (bool, error) {
+		calls++
+		return attempt == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("PollUntil() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+// TestPollUntilTimeout verifies that an unmet condition ends with ErrWaitTimeout.
+func TestPollUntilTimeout(t *testing.T) {
+	opts := PollOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: 30 * time.Millisecond}
+	err := PollUntil(context.Background(), opts, func(ctx context.Context, attempt int) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Errorf("PollUntil() error = %v, want %v", err, ErrWaitTimeout)
+	}
+}
+
+// TestPollUntilCancelled verifies that cancelling the parent context is reported as such and
+// not mistaken for a timeout.
+func TestPollUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := PollUntil(ctx, PollOptions{Interval: time.Millisecond, Timeout: time.Minute}, func(ctx context.Context, attempt int) (bool, error) {
+		if attempt == 2 {
+			cancel()
+		}
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("PollUntil() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -33,8 +33,9 @@ func GenerateTimestampSuffix() string {
 	return fmt.Sprintf("%s%03d", now.Format("20060102150405"), millisecond)
 }
 
-// GenerateTemporalSuffix returns a unique suffix in the format timestamp-randomOrCustom.
-func GenerateTemporalSuffix(customSuffix string) string {
+// NewRunID returns the identifier shared by all resources generated in one run, in the format
+// timestamp-randomOrCustom.
+func NewRunID(customSuffix string) string {
 	normalizedSuffix := normalizeCustomSuffix(customSuffix)
 	if normalizedSuffix == "" {
 		normalizedSuffix = generateShortRandomSuffix(defaultRandomSuffixLength)
@@ -42,51 +43,15 @@ func GenerateTemporalSuffix(customSuffix string) string {
 	return fmt.Sprintf("%s-%s", GenerateTimestampSuffix(), normalizedSuffix)
 }
 
-// GenerateUsernameWithTimestamp builds a GitLab-safe username as prefix-temporalSuffix.
-func GenerateUsernameWithTimestamp(prefix, customSuffix string) string {
-	temporalSuffix := GenerateTemporalSuffix(customSuffix)
-	username := fmt.Sprintf("%s-%s", prefix, temporalSuffix)
-	// Ensure username follows GitLab username rules.
-	username = sanitizeUsername(username)
-	// Enforce GitLab username max length.
-	if len(username) > 255 {
-		username = username[:255]
-	}
-	return username
-}
-
-// GenerateEmailWithTimestamp builds an email as localPart-temporalSuffix@domain.
-func GenerateEmailWithTimestamp(emailPrefix, customSuffix string) string {
-	// Split local part and domain.
-	parts := strings.Split(emailPrefix, "@")
-	if len(parts) != 2 {
-		// Fall back to a default test domain when input is not an email.
-		return fmt.Sprintf("%s-%s@test.example.com", emailPrefix, GenerateTemporalSuffix(customSuffix))
-	}
-
-	localPart := parts[0]
-	domain := parts[1]
-	temporalSuffix := GenerateTemporalSuffix(customSuffix)
-
-	return fmt.Sprintf("%s-%s@%s", localPart, temporalSuffix, domain)
-}
-
-// GenerateGroupPathWithTimestamp builds a GitLab-safe group path as prefix-temporalSuffix.
-func GenerateGroupPathWithTimestamp(prefix, customSuffix string) string {
-	temporalSuffix := GenerateTemporalSuffix(customSuffix)
-	path := fmt.Sprintf("%s-%s", prefix, temporalSuffix)
-	// Ensure path follows GitLab group path rules.
-	path = sanitizeGroupPath(path)
-	// Enforce GitLab path max length.
-	if len(path) > 255 {
-		path = path[:255]
-	}
-	return path
+// NormalizeRunID sanitizes a run ID passed on the command line so it is safe inside usernames,
+// emails and paths.
+func NormalizeRunID(runID string) string {
+	return normalizeCustomSuffix(runID)
 }
 
-// GenerateProjectPathWithTimestamp builds a GitLab-safe project path as prefix-temporalSuffix.
-func GenerateProjectPathWithTimestamp(prefix, customSuffix string) string {
-	return GenerateGroupPathWithTimestamp(prefix, customSuffix)
+// HasRunID reports whether name was generated from runID, i.e. ends with -runID.
+func HasRunID(name, runID string) bool {
+	return runID != "" && strings.HasSuffix(strings.ToLower(name), "-"+runID)
 }
 
 // normalizeCustomSuffix sanitizes a custom suffix to safe characters and lowercase.
@@ -123,30 +88,3 @@ func generateShortRandomSuffix(length int) string {
 
 	return string(randomSuffix)
 }
-
-// sanitizeUsername sanitizes username to comply with GitLab username rules.
-func sanitizeUsername(username string) string {
-	// Remove unsupported characters.
-	reg := regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
-	username = reg.ReplaceAllString(username, "")
-
-	// Ensure username does not start/end with hyphen or dot.
-	username = strings.Trim(username, "-.")
-
-	return username
-}
-
-// sanitizeGroupPath sanitizes group/project path to comply with GitLab path rules.
-func sanitizeGroupPath(path string) string {
-	// Normalize to lowercase.
-	path = strings.ToLower(path)
-
-	// Remove unsupported characters.
-	reg := regexp.MustCompile(`[^a-z0-9_-]`)
-	path = reg.ReplaceAllString(path, "")
-
-	// Ensure path does not start/end with hyphen.
-	path = strings.Trim(path, "-")
-
-	return path
-}
@@ -0,0 +1,15 @@
+package utils
+
+import "testing"
+
+// TestHasRunID verifies that cleanup recognises names generated from a run.
+func TestHasRunID(t *testing.T) {
+	runID := "20251030150000123-ci01"
+
+	if !HasRunID("tektoncd-20251030150000123-ci01", runID) {
+		t.Errorf("HasRunID() = false for a name generated from the run")
+	}
+	if HasRunID("tektoncd-20251030150000123-ci02", runID) || HasRunID("tektoncd", "") {
+		t.Errorf("HasRunID() = true for a name from another run")
+	}
+}
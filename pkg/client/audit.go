@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Redacted 替换审计记录中密码等敏感的请求参数
+const Redacted = "[REDACTED]"
+
+// AuditEntry 记录一次修改 GitLab 数据的 API 调用（包含重试）
+type AuditEntry struct {
+	Time      time.Time      // 调用开始时间
+	Operation string         // 客户端方法名，例如 CreateUser、DeleteGroup
+	Actor     string         // CheckAuth 确认的调用者用户名，未认证时为空
+	Sudo      string         // 以该用户身份（sudo）调用，为空表示以调用者身份
+	Target    string         // 目标资源 <类型>:<路径>#<ID>，例如 user:alice#7、group:team#12；ID 未知时省略 #<ID>
+	Params    map[string]any // 请求参数，密码等敏感值已替换为 Redacted
+	Status    int            // 最后一次请求的 HTTP 状态码，没有收到响应或 SDK 不返回响应时为 0
+	Attempts  int            // 请求次数
+	Duration  time.Duration  // 包含重试等待的总耗时
+	Err       error          // 调用失败的原因
+}
+
+// Auditor 接收每次修改性 API 调用的审计记录，可能在多个 goroutine 中并发调用
+type Auditor interface {
+	Audit(entry AuditEntry)
+}
+
+// WithAuditor 设置接收审计记录的 Auditor；未设置时不记录
+func WithAuditor(auditor Auditor) Option {
+	return func(o *options) {
+		o.auditor = auditor
+	}
+}
+
+// mutation 描述一次修改性调用的审计信息。kind、path 和 id 组成审计记录的目标，
+// 创建和删除同一资源的记录使用相同的目标；创建调用在得到资源后填写 id
+type mutation struct {
+	operation string
+	sudo      string
+	kind      string // user、group 或 project
+	path      string // 用户名、组 full path 或项目 full path
+	id        int
+	params    map[string]any
+}
+
+// target 返回审计记录的目标 <kind>:<path>#<id>，id 未知时省略 #<id>
+func (m *mutation) target() string {
+	target := m.kind + ":" + m.path
+	if m.id != 0 {
+		target += "#" + strconv.Itoa(m.id)
+	}
+	return target
+}
+
+// withAudit 按重试策略执行修改性调用，结束后把整个调用记录为一条审计记录
+func (c *GitLabClient) withAudit(ctx context.Context, m *mutation, kind callKind, call func() (*gitlab.Response, error), recheck func() (bool, error)) error {
+	started := time.Now()
+	var status, attempts int
+	err := c.withRetry(ctx, m.operation, kind, func() (*gitlab.Response, error) {
+		attempts++
+		resp, err := call()
+		status = responseStatus(resp, err)
+		return resp, err
+	}, recheck)
+
+	if c.auditor != nil {
+		c.auditor.Audit(AuditEntry{
+			Time:      started,
+			Operation: m.operation,
+			Actor:     c.actor,
+			Sudo:      m.sudo,
+			Target:    m.target(),
+			Params:    m.params,
+			Status:    status,
+			Attempts:  attempts,
+			Duration:  time.Since(started),
+			Err:       err,
+		})
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// auditLog collects audit entries in memory.
+type auditLog struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+func (l *auditLog) Audit(entry AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// TestAuditTargetStable verifies that creating and deleting a resource are journaled with the
+// same target, so that both records are found by either the path or the ID.
+func TestAuditTargetStable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":12,"path":"team","full_path":"team"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	log := &auditLog{}
+	c, err := NewGitLabClient(server.URL, "token", WithAuditor(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	group, err := c.CreateGroup(ctx, "bot", "Team", "team", "private")
+	if err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+	if err := c.DeleteGroup(ctx, group.ID, group.FullPath); err != nil {
+		t.Fatalf("DeleteGroup() error = %v", err)
+	}
+
+	if len(log.entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(log.entries))
+	}
+	for _, entry := range log.entries {
+		if entry.Target != "group:team#12" {
+			t.Errorf("%s target = %q, want group:team#12", entry.Operation, entry.Target)
+		}
+	}
+}
@@ -1,37 +1,106 @@
 package client
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"golang.org/x/time/rate"
+
+	"gitlab-cli-sdk/internal/i18n"
 )
 
 // GitLabClient GitLab SDK 客户端封装
 type GitLabClient struct {
 	client *gitlab.Client
+	// retry controls how transient API failures are retried.
+	retry RetryPolicy
+	// stats counts the retries performed by this client.
+	stats *RetryStats
+	// auditor receives an entry for every mutating call; nil disables auditing.
+	auditor Auditor
+	// actor is the username confirmed by CheckAuth, recorded in audit entries.
+	actor string
+}
+
+// Option 配置 GitLabClient 的可选行为
+type Option func(*options)
+
+// options 汇总 NewGitLabClient 的可选配置
+type options struct {
+	// rateLimit is the maximum number of requests per second; zero means unlimited.
+	rateLimit float64
+	// retry is the policy applied to transient API failures.
+	retry RetryPolicy
+	// auditor receives an entry for every mutating call.
+	auditor Auditor
+}
+
+// WithRateLimit 限制每秒请求数，该限制由所有使用同一客户端的 goroutine 共享
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(o *options) {
+		o.rateLimit = requestsPerSecond
+	}
+}
+
+// WithRetryPolicy 设置瞬时错误的重试策略，默认使用 DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
 }
 
 // NewGitLabClient 创建新的 GitLab 客户端
-func NewGitLabClient(host, token string) (*GitLabClient, error) {
-	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(host+"/api/v4"))
+func NewGitLabClient(host, token string, opts ...Option) (*GitLabClient, error) {
+	o := options{retry: DefaultRetryPolicy()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// The SDK's built-in retries are disabled so withRetry is the only retry layer and every
+	// retry is counted.
+	clientOpts := []gitlab.ClientOptionFunc{gitlab.WithBaseURL(host + "/api/v4"), gitlab.WithoutRetries()}
+	if o.rateLimit > 0 {
+		// burst allows short spikes of roughly one second worth of requests
+		burst := int(o.rateLimit)
+		if burst < 1 {
+			burst = 1
+		}
+		clientOpts = append(clientOpts, gitlab.WithCustomLimiter(rate.NewLimiter(rate.Limit(o.rateLimit), burst)))
+	}
+
+	client, err := gitlab.NewClient(token, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
 	return &GitLabClient{
-		client: client,
+		client:  client,
+		retry:   o.retry,
+		stats:   &RetryStats{},
+		auditor: o.auditor,
 	}, nil
 }
 
+// RetryStats 返回该客户端的重试统计
+func (c *GitLabClient) RetryStats() *RetryStats {
+	return c.stats
+}
+
 // CloseIdleConnections 关闭空闲连接，防止程序退出时卡住
 func (c *GitLabClient) CloseIdleConnections() {
 	c.client.HTTPClient().CloseIdleConnections()
 }
 
 // CheckAuth 检查认证和管理员权限
-func (c *GitLabClient) CheckAuth() error {
-	user, _, err := c.client.Users.CurrentUser()
+func (c *GitLabClient) CheckAuth(ctx context.Context) error {
+	var user *gitlab.User
+	err := c.withRetry(ctx, "CheckAuth", idempotentCall, func() (resp *gitlab.Response, err error) {
+		user, resp, err = c.client.Users.CurrentUser(gitlab.WithContext(ctx))
+		return resp, err
+	}, nil)
 	if err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
@@ -40,15 +109,20 @@ func (c *GitLabClient) CheckAuth() error {
 		return fmt.Errorf("current user is not admin")
 	}
 
-	log.Printf("✓ 认证成功（用户: %s, 管理员权限）\n", user.Username)
+	c.actor = user.Username
+	slog.Info(i18n.T("认证成功（管理员权限）"), "username", user.Username)
 	return nil
 }
 
 // GetUser 获取用户
-func (c *GitLabClient) GetUser(username string) (*gitlab.User, error) {
-	users, _, err := c.client.Users.ListUsers(&gitlab.ListUsersOptions{
-		Username: gitlab.Ptr(username),
-	})
+func (c *GitLabClient) GetUser(ctx context.Context, username string) (*gitlab.User, error) {
+	var users []*gitlab.User
+	err := c.withRetry(ctx, "GetUser", idempotentCall, func() (resp *gitlab.Response, err error) {
+		users, resp, err = c.client.Users.ListUsers(&gitlab.ListUsersOptions{
+			Username: gitlab.Ptr(username),
+		}, gitlab.WithContext(ctx))
+		return resp, err
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +135,15 @@ func (c *GitLabClient) GetUser(username string) (*gitlab.User, error) {
 }
 
 // GetUserNamespaceID 获取用户的 namespace ID
-func (c *GitLabClient) GetUserNamespaceID(username string) (int, error) {
+func (c *GitLabClient) GetUserNamespaceID(ctx context.Context, username string) (int, error) {
 	// 列出用户的所有 namespace
-	namespaces, _, err := c.client.Namespaces.ListNamespaces(&gitlab.ListNamespacesOptions{
-		Search: gitlab.Ptr(username),
-	}, gitlab.WithSudo(username))
+	var namespaces []*gitlab.Namespace
+	err := c.withRetry(ctx, "GetUserNamespaceID", idempotentCall, func() (resp *gitlab.Response, err error) {
+		namespaces, resp, err = c.client.Namespaces.ListNamespaces(&gitlab.ListNamespacesOptions{
+			Search: gitlab.Ptr(username),
+		}, gitlab.WithContext(ctx), gitlab.WithSudo(username))
+		return resp, err
+	}, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -77,21 +155,25 @@ func (c *GitLabClient) GetUserNamespaceID(username string) (int, error) {
 		}
 	}
 
-	return 0, fmt.Errorf("未找到用户 %s 的 namespace", username)
+	return 0, fmt.Errorf(i18n.T("未找到用户 %s 的 namespace"), username)
 }
 
 // ListUserProjects 列出用户的个人项目（不属于任何组的项目）
-func (c *GitLabClient) ListUserProjects(username string) ([]*gitlab.Project, error) {
+func (c *GitLabClient) ListUserProjects(ctx context.Context, username string) ([]*gitlab.Project, error) {
 	// 获取用户信息
-	user, err := c.GetUser(username)
+	user, err := c.GetUser(ctx, username)
 	if err != nil || user == nil {
-		return nil, fmt.Errorf("获取用户信息失败: %w", err)
+		return nil, fmt.Errorf(i18n.T("获取用户信息失败: %w"), err)
 	}
 
 	// 列出用户拥有的所有项目，过滤出个人命名空间下的项目
-	projects, _, err := c.client.Projects.ListUserProjects(user.ID, &gitlab.ListProjectsOptions{
-		Owned: gitlab.Ptr(true),
-	})
+	var projects []*gitlab.Project
+	err = c.withRetry(ctx, "ListUserProjects", idempotentCall, func() (resp *gitlab.Response, err error) {
+		projects, resp, err = c.client.Projects.ListUserProjects(user.ID, &gitlab.ListProjectsOptions{
+			Owned: gitlab.Ptr(true),
+		}, gitlab.WithContext(ctx))
+		return resp, err
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -108,40 +190,70 @@ func (c *GitLabClient) ListUserProjects(username string) ([]*gitlab.Project, err
 }
 
 // CreateUser 创建用户
-func (c *GitLabClient) CreateUser(username, email, name, password string) (*gitlab.User, error) {
-	user, _, err := c.client.Users.CreateUser(&gitlab.CreateUserOptions{
-		Email:            gitlab.Ptr(email),
-		Username:         gitlab.Ptr(username),
-		Name:             gitlab.Ptr(name),
-		Password:         gitlab.Ptr(password),
-		SkipConfirmation: gitlab.Ptr(true),
+func (c *GitLabClient) CreateUser(ctx context.Context, username, email, name, password string) (*gitlab.User, error) {
+	var user *gitlab.User
+	m := &mutation{operation: "CreateUser", kind: "user", path: username, params: map[string]any{
+		"username": username,
+		"email":    email,
+		"name":     name,
+		"password": Redacted,
+	}}
+	err := c.withAudit(ctx, m, recheckableCall, func() (resp *gitlab.Response, err error) {
+		user, resp, err = c.client.Users.CreateUser(&gitlab.CreateUserOptions{
+			Email:            gitlab.Ptr(email),
+			Username:         gitlab.Ptr(username),
+			Name:             gitlab.Ptr(name),
+			Password:         gitlab.Ptr(password),
+			SkipConfirmation: gitlab.Ptr(true),
+		}, gitlab.WithContext(ctx))
+		if user != nil {
+			m.id = user.ID
+		}
+		return resp, err
+	}, func() (bool, error) {
+		// 上一次请求可能已在服务端成功，只是响应丢失
+		existing, err := c.GetUser(ctx, username)
+		if existing != nil {
+			user, m.id = existing, existing.ID
+		}
+		return existing != nil, err
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	// 确保用户激活和批准
-	err = c.client.Users.UnblockUser(user.ID)
+	m = &mutation{operation: "UnblockUser", kind: "user", path: username, id: user.ID}
+	err = c.withAudit(ctx, m, idempotentCall, func() (*gitlab.Response, error) {
+		return nil, c.client.Users.UnblockUser(user.ID, gitlab.WithContext(ctx))
+	}, nil)
 	if err != nil {
-		log.Printf("  ⚠ 解除封锁用户失败: %v\n", err)
+		slog.Warn(i18n.T("解除封锁用户失败"), "username", username, "error", err)
 	}
 
-	err = c.client.Users.ApproveUser(user.ID)
+	m = &mutation{operation: "ApproveUser", kind: "user", path: username, id: user.ID}
+	err = c.withAudit(ctx, m, idempotentCall, func() (*gitlab.Response, error) {
+		return nil, c.client.Users.ApproveUser(user.ID, gitlab.WithContext(ctx))
+	}, nil)
 	if err != nil {
-		log.Printf("  ⚠ 批准用户失败: %v\n", err)
+		slog.Warn(i18n.T("批准用户失败"), "username", username, "error", err)
 	}
 
 	return user, nil
 }
 
 // GetGroup 获取组
-func (c *GitLabClient) GetGroup(groupPath string) (*gitlab.Group, error) {
-	group, resp, err := c.client.Groups.GetGroup(groupPath, &gitlab.GetGroupOptions{})
-	if err != nil {
+func (c *GitLabClient) GetGroup(ctx context.Context, groupPath string) (*gitlab.Group, error) {
+	var group *gitlab.Group
+	err := c.withRetry(ctx, "GetGroup", idempotentCall, func() (resp *gitlab.Response, err error) {
+		group, resp, err = c.client.Groups.GetGroup(groupPath, &gitlab.GetGroupOptions{}, gitlab.WithContext(ctx))
 		// 404 表示组不存在
-		if resp != nil && resp.StatusCode == 404 {
-			return nil, nil
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			group, err = nil, nil
 		}
+		return resp, err
+	}, nil)
+	if err != nil {
 		return nil, err
 	}
 
@@ -149,15 +261,33 @@ func (c *GitLabClient) GetGroup(groupPath string) (*gitlab.Group, error) {
 }
 
 // CreateGroup 创建组
-func (c *GitLabClient) CreateGroup(username, groupName, groupPath, visibility string) (*gitlab.Group, error) {
+func (c *GitLabClient) CreateGroup(ctx context.Context, username, groupName, groupPath, visibility string) (*gitlab.Group, error) {
 	vis := gitlab.VisibilityValue(visibility)
 
-	group, _, err := c.client.Groups.CreateGroup(&gitlab.CreateGroupOptions{
-		Name:                 gitlab.Ptr(groupName),
-		Path:                 gitlab.Ptr(groupPath),
-		Visibility:           &vis,
-		RequestAccessEnabled: gitlab.Ptr(false),
-	}, gitlab.WithSudo(username))
+	var group *gitlab.Group
+	m := &mutation{operation: "CreateGroup", sudo: username, kind: "group", path: groupPath, params: map[string]any{
+		"name":       groupName,
+		"path":       groupPath,
+		"visibility": visibility,
+	}}
+	err := c.withAudit(ctx, m, recheckableCall, func() (resp *gitlab.Response, err error) {
+		group, resp, err = c.client.Groups.CreateGroup(&gitlab.CreateGroupOptions{
+			Name:                 gitlab.Ptr(groupName),
+			Path:                 gitlab.Ptr(groupPath),
+			Visibility:           &vis,
+			RequestAccessEnabled: gitlab.Ptr(false),
+		}, gitlab.WithContext(ctx), gitlab.WithSudo(username))
+		if group != nil {
+			m.id = group.ID
+		}
+		return resp, err
+	}, func() (bool, error) {
+		existing, err := c.GetGroup(ctx, groupPath)
+		if existing != nil {
+			group, m.id = existing, existing.ID
+		}
+		return existing != nil, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -166,34 +296,59 @@ func (c *GitLabClient) CreateGroup(username, groupName, groupPath, visibility st
 }
 
 // GetProject 获取项目
-func (c *GitLabClient) GetProject(fullPath string) (*gitlab.Project, error) {
-	project, resp, err := c.client.Projects.GetProject(fullPath, &gitlab.GetProjectOptions{})
-	if err != nil {
+func (c *GitLabClient) GetProject(ctx context.Context, fullPath string) (*gitlab.Project, error) {
+	var project *gitlab.Project
+	err := c.withRetry(ctx, "GetProject", idempotentCall, func() (resp *gitlab.Response, err error) {
+		project, resp, err = c.client.Projects.GetProject(fullPath, &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
 		// 404 表示项目不存在
-		if resp != nil && resp.StatusCode == 404 {
-			return nil, nil
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			project, err = nil, nil
 		}
+		return resp, err
+	}, nil)
+	if err != nil {
 		return nil, err
 	}
 
 	return project, nil
 }
 
-// CreateProject 创建项目
-func (c *GitLabClient) CreateProject(username string, namespaceID int, projectName, projectPath, description, visibility string) (*gitlab.Project, error) {
+// CreateProject 在 namespace 下创建项目，namespacePath 是 namespace 的 full path（组 path 或用户名）
+func (c *GitLabClient) CreateProject(ctx context.Context, username string, namespaceID int, namespacePath, projectName, projectPath, description, visibility string) (*gitlab.Project, error) {
 	vis := gitlab.VisibilityValue(visibility)
-
-	project, _, err := c.client.Projects.CreateProject(&gitlab.CreateProjectOptions{
-		Name:                 gitlab.Ptr(projectName),
-		Path:                 gitlab.Ptr(projectPath),
-		NamespaceID:          gitlab.Ptr(namespaceID),
-		Description:          gitlab.Ptr(description),
-		Visibility:           &vis,
-		InitializeWithReadme: gitlab.Ptr(true),
-		IssuesEnabled:        gitlab.Ptr(true),
-		MergeRequestsEnabled: gitlab.Ptr(true),
-		WikiEnabled:          gitlab.Ptr(true),
-	}, gitlab.WithSudo(username))
+	fullPath := namespacePath + "/" + projectPath
+
+	var project *gitlab.Project
+	m := &mutation{operation: "CreateProject", sudo: username, kind: "project", path: fullPath, params: map[string]any{
+		"name":         projectName,
+		"path":         projectPath,
+		"namespace_id": namespaceID,
+		"description":  description,
+		"visibility":   visibility,
+	}}
+	err := c.withAudit(ctx, m, recheckableCall, func() (resp *gitlab.Response, err error) {
+		project, resp, err = c.client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+			Name:                 gitlab.Ptr(projectName),
+			Path:                 gitlab.Ptr(projectPath),
+			NamespaceID:          gitlab.Ptr(namespaceID),
+			Description:          gitlab.Ptr(description),
+			Visibility:           &vis,
+			InitializeWithReadme: gitlab.Ptr(true),
+			IssuesEnabled:        gitlab.Ptr(true),
+			MergeRequestsEnabled: gitlab.Ptr(true),
+			WikiEnabled:          gitlab.Ptr(true),
+		}, gitlab.WithContext(ctx), gitlab.WithSudo(username))
+		if project != nil {
+			m.id = project.ID
+		}
+		return resp, err
+	}, func() (bool, error) {
+		existing, err := c.GetProject(ctx, fullPath)
+		if existing != nil {
+			project, m.id = existing, existing.ID
+		}
+		return existing != nil, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -201,27 +356,35 @@ func (c *GitLabClient) CreateProject(username string, namespaceID int, projectNa
 	return project, nil
 }
 
-// DeleteProject 删除项目
-func (c *GitLabClient) DeleteProject(projectID int) error {
-	_, err := c.client.Projects.DeleteProject(projectID, nil)
-	return err
+// DeleteProject 删除项目，fullPath 只用于审计记录
+func (c *GitLabClient) DeleteProject(ctx context.Context, projectID int, fullPath string) error {
+	m := &mutation{operation: "DeleteProject", kind: "project", path: fullPath, id: projectID}
+	return c.withAudit(ctx, m, idempotentCall, retriedDelete(func() (*gitlab.Response, error) {
+		return c.client.Projects.DeleteProject(projectID, nil, gitlab.WithContext(ctx))
+	}), nil)
 }
 
-// DeleteGroup 删除组
-func (c *GitLabClient) DeleteGroup(groupID int) error {
-	_, err := c.client.Groups.DeleteGroup(groupID, nil)
-	return err
+// DeleteGroup 删除组，groupPath 只用于审计记录
+func (c *GitLabClient) DeleteGroup(ctx context.Context, groupID int, groupPath string) error {
+	m := &mutation{operation: "DeleteGroup", kind: "group", path: groupPath, id: groupID}
+	return c.withAudit(ctx, m, idempotentCall, retriedDelete(func() (*gitlab.Response, error) {
+		return c.client.Groups.DeleteGroup(groupID, nil, gitlab.WithContext(ctx))
+	}), nil)
 }
 
 // ListUserGroups 列出用户拥有的所有组
-func (c *GitLabClient) ListUserGroups(username string) ([]*gitlab.Group, error) {
+func (c *GitLabClient) ListUserGroups(ctx context.Context, username string) ([]*gitlab.Group, error) {
 	// 获取用户拥有的组
 	opts := &gitlab.ListGroupsOptions{
 		Owned:       gitlab.Ptr(true),
 		ListOptions: gitlab.ListOptions{PerPage: 100},
 	}
 
-	groups, _, err := c.client.Groups.ListGroups(opts, gitlab.WithSudo(username))
+	var groups []*gitlab.Group
+	err := c.withRetry(ctx, "ListUserGroups", idempotentCall, func() (resp *gitlab.Response, err error) {
+		groups, resp, err = c.client.Groups.ListGroups(opts, gitlab.WithContext(ctx), gitlab.WithSudo(username))
+		return resp, err
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -229,16 +392,18 @@ func (c *GitLabClient) ListUserGroups(username string) ([]*gitlab.Group, error)
 	return groups, nil
 }
 
-// DeleteUser 删除用户
-func (c *GitLabClient) DeleteUser(userID int) error {
+// DeleteUser 删除用户，username 只用于审计记录
+func (c *GitLabClient) DeleteUser(ctx context.Context, userID int, username string) error {
 	// 注意：GitLab 的用户删除可能是"软删除"，用户会被标记为删除但仍然存在
 	// 完全删除用户可能需要一段时间，或者用户会保留在系统中但处于非活跃状态
-	_, err := c.client.Users.DeleteUser(userID)
-	return err
+	m := &mutation{operation: "DeleteUser", kind: "user", path: username, id: userID}
+	return c.withAudit(ctx, m, idempotentCall, retriedDelete(func() (*gitlab.Response, error) {
+		return c.client.Users.DeleteUser(userID, gitlab.WithContext(ctx))
+	}), nil)
 }
 
-// CreatePersonalAccessToken 为用户创建 Personal Access Token
-func (c *GitLabClient) CreatePersonalAccessToken(userID int, name string, scopes []string, expiresAt string) (string, error) {
+// CreatePersonalAccessToken 为用户创建 Personal Access Token，username 只用于审计记录
+func (c *GitLabClient) CreatePersonalAccessToken(ctx context.Context, userID int, username, name string, scopes []string, expiresAt string) (string, error) {
 	// 将字符串日期转换为 ISOTime 类型
 	isoTime, err := gitlab.ParseISOTime(expiresAt)
 	if err != nil {
@@ -251,7 +416,17 @@ func (c *GitLabClient) CreatePersonalAccessToken(userID int, name string, scopes
 		ExpiresAt: &isoTime,
 	}
 
-	token, _, err := c.client.Users.CreatePersonalAccessToken(userID, opt)
+	// Token 的值只在创建响应中返回，无法事后查询，因此只在请求确定未被处理时（429）重试
+	var token *gitlab.PersonalAccessToken
+	m := &mutation{operation: "CreatePersonalAccessToken", kind: "user", path: username, id: userID, params: map[string]any{
+		"name":       name,
+		"scopes":     scopes,
+		"expires_at": expiresAt,
+	}}
+	err = c.withAudit(ctx, m, unsafeCall, func() (resp *gitlab.Response, err error) {
+		token, resp, err = c.client.Users.CreatePersonalAccessToken(userID, opt, gitlab.WithContext(ctx))
+		return resp, err
+	}, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create personal access token: %w", err)
 	}
@@ -260,8 +435,27 @@ func (c *GitLabClient) CreatePersonalAccessToken(userID int, name string, scopes
 	return token.Token, nil
 }
 
+// GetTokenSelf 使用 token 本身请求 /personal_access_tokens/self，返回该 token 的信息；
+// token 无法认证（无效、已撤销或已过期）时返回 nil
+func (c *GitLabClient) GetTokenSelf(ctx context.Context, token string) (*gitlab.PersonalAccessToken, error) {
+	var pat *gitlab.PersonalAccessToken
+	err := c.withRetry(ctx, "GetTokenSelf", idempotentCall, func() (resp *gitlab.Response, err error) {
+		pat, resp, err = c.client.PersonalAccessTokens.GetSinglePersonalAccessToken(gitlab.WithContext(ctx), gitlab.WithToken(gitlab.PrivateToken, token))
+		// 401 表示 token 无法认证
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			pat, err = nil, nil
+		}
+		return resp, err
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return pat, nil
+}
+
 // ListAllUsers 列出所有用户（支持搜索过滤）
-func (c *GitLabClient) ListAllUsers(searchPrefix string) ([]*gitlab.User, error) {
+func (c *GitLabClient) ListAllUsers(ctx context.Context, searchPrefix string) ([]*gitlab.User, error) {
 	var allUsers []*gitlab.User
 	page := 1
 	perPage := 100
@@ -279,7 +473,13 @@ func (c *GitLabClient) ListAllUsers(searchPrefix string) ([]*gitlab.User, error)
 			opts.Search = gitlab.Ptr(searchPrefix)
 		}
 
-		users, resp, err := c.client.Users.ListUsers(opts)
+		var users []*gitlab.User
+		var resp *gitlab.Response
+		err := c.withRetry(ctx, "ListAllUsers", idempotentCall, func() (*gitlab.Response, error) {
+			var err error
+			users, resp, err = c.client.Users.ListUsers(opts, gitlab.WithContext(ctx))
+			return resp, err
+		}, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list users: %w", err)
 		}
@@ -296,3 +496,16 @@ func (c *GitLabClient) ListAllUsers(searchPrefix string) ([]*gitlab.User, error)
 
 	return allUsers, nil
 }
+
+// retriedDelete 包装删除调用：重试时若返回 404，说明之前的某次尝试已删除成功
+func retriedDelete(call func() (*gitlab.Response, error)) func() (*gitlab.Response, error) {
+	attempts := 0
+	return func() (*gitlab.Response, error) {
+		attempts++
+		resp, err := call()
+		if err != nil && attempts > 1 && resp != nil && resp.StatusCode == http.StatusNotFound {
+			return resp, nil
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"gitlab-cli-sdk/internal/i18n"
+	"gitlab-cli-sdk/internal/metrics"
+	"gitlab-cli-sdk/internal/tracing"
+)
+
+// RetryPolicy 描述瞬时错误（5xx、429、409 等）的重试策略
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per call, including the first one; 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles with every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff. Server-provided Retry-After and RateLimit-Reset
+	// values are honoured even when they are longer.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy 返回默认的重试策略
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// callKind 描述一次 API 调用在失败后能否安全重试
+type callKind int
+
+const (
+	// idempotentCall may be repeated freely (reads and deletes).
+	idempotentCall callKind = iota
+	// recheckableCall creates a resource whose existence can be checked before retrying.
+	recheckableCall
+	// unsafeCall must only be retried when GitLab certainly did not process the request (429).
+	unsafeCall
+)
+
+// pendingDeletionOperations 是目标命名空间仍在删除中时 GitLab 会返回 409 的调用：在正在删除的
+// 命名空间中创建组或项目，以及删除。其他调用的 409（用户名、邮箱或路径已被占用）不会自行消失，不重试
+var pendingDeletionOperations = map[string]bool{
+	"CreateGroup":   true,
+	"CreateProject": true,
+	"DeleteGroup":   true,
+	"DeleteProject": true,
+	"DeleteUser":    true,
+}
+
+// RetryStats 统计一次运行中的重试情况
+type RetryStats struct {
+	mu sync.Mutex
+	// total counts all retries across operations.
+	total int
+	// byOperation counts retries per client operation name.
+	byOperation map[string]int
+}
+
+// record 记录一次重试
+func (s *RetryStats) record(operation string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byOperation == nil {
+		s.byOperation = make(map[string]int)
+	}
+	s.total++
+	s.byOperation[operation]++
+}
+
+// Total 返回重试总次数
+func (s *RetryStats) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.total
+}
+
+// ByOperation 返回每个操作的重试次数
+func (s *RetryStats) ByOperation() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]int, len(s.byOperation))
+	for op, n := range s.byOperation {
+		counts[op] = n
+	}
+	return counts
+}
+
+// withRetry 按重试策略执行 call。call 返回的 *gitlab.Response 用于判断状态码和读取限流头。
+// recheckableCall 在重试前先调用 recheck，recheck 返回 true 表示资源其实已创建成功，不再重试。
+// 整个调用（包含重试和等待）记录为 context 中当前 span 的一个子 span，每次请求计入 context 中的指标
+func (c *GitLabClient) withRetry(ctx context.Context, operation string, kind callKind, call func() (*gitlab.Response, error), recheck func() (bool, error)) error {
+	_, span := tracing.StartClient(ctx, operation)
+	registry := metrics.FromContext(ctx)
+	var attempts, status int
+	err := c.retryLoop(ctx, operation, kind, func() (*gitlab.Response, error) {
+		attempts++
+		started := time.Now()
+		resp, err := call()
+		status = responseStatus(resp, err)
+		registry.ObserveAPICall(operation, status, time.Since(started))
+		return resp, err
+	}, recheck)
+	span.SetAttributes(slog.Int("attempts", attempts))
+	if status != 0 {
+		span.SetAttributes(slog.Int("http.response.status_code", status))
+	}
+	span.End(err)
+	return err
+}
+
+// retryLoop 执行 call，遇到瞬时错误时按退避时间重试
+func (c *GitLabClient) retryLoop(ctx context.Context, operation string, kind callKind, call func() (*gitlab.Response, error), recheck func() (bool, error)) error {
+	maxAttempts := c.retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := call()
+		if err == nil {
+			return nil
+		}
+
+		statusCode := responseStatus(resp, err)
+		if attempt >= maxAttempts || ctx.Err() != nil || !shouldRetry(operation, kind, statusCode, err) {
+			return err
+		}
+
+		delay := c.retryDelay(attempt, resp)
+		c.stats.record(operation)
+		metrics.FromContext(ctx).ObserveRetry(operation)
+		slog.Warn(i18n.T("API 调用失败，稍后重试"), "operation", operation, "status", statusCode, "error", err,
+			"delay", delay, "attempt", attempt+1, "max_attempts", maxAttempts)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		if kind == recheckableCall && recheck != nil {
+			if exists, checkErr := recheck(); checkErr == nil && exists {
+				return nil
+			}
+		}
+	}
+}
+
+// responseStatus 返回失败请求的 HTTP 状态码；没有收到响应时返回 0
+func responseStatus(resp *gitlab.Response, err error) int {
+	if resp != nil {
+		return resp.StatusCode
+	}
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		return errResp.Response.StatusCode
+	}
+	return 0
+}
+
+// shouldRetry 判断一次失败是否是值得重试的瞬时错误
+func shouldRetry(operation string, kind callKind, statusCode int, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		// 429 means the request was rejected before being processed, so every kind is safe.
+		return true
+	case kind == unsafeCall:
+		return false
+	case statusCode == 0:
+		// No response at all: connection reset, DNS failure, timeout.
+		return true
+	case statusCode == http.StatusConflict:
+		// GitLab answers 409 while a namespace is still being deleted.
+		return pendingDeletionOperations[operation]
+	case statusCode == http.StatusBadGateway, statusCode == http.StatusServiceUnavailable,
+		statusCode == http.StatusGatewayTimeout, statusCode == http.StatusInternalServerError:
+		return true
+	}
+	return false
+}
+
+// retryDelay 计算第 attempt 次失败后的等待时间：带抖动的指数退避，并遵循服务端的限流头
+func (c *GitLabClient) retryDelay(attempt int, resp *gitlab.Response) time.Duration {
+	backoff := c.retry.BaseDelay << (attempt - 1)
+	if backoff <= 0 || (c.retry.MaxDelay > 0 && backoff > c.retry.MaxDelay) {
+		backoff = c.retry.MaxDelay
+	}
+	// Equal jitter keeps at least half of the backoff so retries still spread out.
+	delay := backoff/2 + time.Duration(rand.Int64N(int64(backoff/2)+1))
+
+	if resp != nil {
+		if serverDelay, ok := serverRetryDelay(resp.Header, time.Now()); ok && serverDelay > delay {
+			delay = serverDelay
+		}
+	}
+	return delay
+}
+
+// serverRetryDelay 从 Retry-After 或 RateLimit-* 响应头中解析服务端要求的等待时间
+func serverRetryDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return max(at.Sub(now), 0), true
+		}
+	}
+
+	// GitLab reports the reset time of the rate limit window as a Unix timestamp. Only wait for
+	// it once the window is exhausted.
+	if header.Get("RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+			return max(time.Unix(reset, 0).Sub(now), 0), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestShouldRetry verifies which failures are treated as transient for each kind of call.
+func TestShouldRetry(t *testing.T) {
+	apiErr := errors.New("api error")
+	tests := []struct {
+		name       string
+		operation  string
+		kind       callKind
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{"read on 502", "GetUser", idempotentCall, http.StatusBadGateway, apiErr, true},
+		{"read on 404", "GetGroup", idempotentCall, http.StatusNotFound, apiErr, false},
+		{"delete on 409 still being deleted", "DeleteGroup", idempotentCall, http.StatusConflict, apiErr, true},
+		{"project create on 409 namespace being deleted", "CreateProject", recheckableCall, http.StatusConflict, apiErr, true},
+		{"user create on 409 email taken", "CreateUser", recheckableCall, http.StatusConflict, apiErr, false},
+		{"create on network error", "CreateGroup", recheckableCall, 0, apiErr, true},
+		{"create on 400", "CreateGroup", recheckableCall, http.StatusBadRequest, apiErr, false},
+		{"token on 429", "CreatePersonalAccessToken", unsafeCall, http.StatusTooManyRequests, apiErr, true},
+		{"token on 502", "CreatePersonalAccessToken", unsafeCall, http.StatusBadGateway, apiErr, false},
+		{"cancelled", "GetUser", idempotentCall, 0, context.Canceled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRetry(tt.operation, tt.kind, tt.statusCode, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateUserConflictNotRetried verifies that a 409 for an existing username or email fails
+// on the first attempt instead of using up the retry budget.
+func TestCreateUserConflictNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"Email has already been taken"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewGitLabClient(server.URL, "token", WithRetryPolicy(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateUser(context.Background(), "alice", "alice@example.com", "Alice", "secret123"); err == nil {
+		t.Fatal("CreateUser() succeeded, want the 409 error")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("CreateUser() sent %d requests, want 1", got)
+	}
+}
+
+// TestServerRetryDelay verifies that Retry-After and RateLimit-* headers are honoured.
+func TestServerRetryDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		wantOK bool
+	}{
+		{"retry-after seconds", http.Header{"Retry-After": {"7"}}, 7 * time.Second, true},
+		{"retry-after date", http.Header{"Retry-After": {now.Add(3 * time.Second).Format(http.TimeFormat)}}, 3 * time.Second, true},
+		{"rate limit exhausted", http.Header{"Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"1735732805"}}, 5 * time.Second, true},
+		{"rate limit not exhausted", http.Header{"Ratelimit-Remaining": {"10"}, "Ratelimit-Reset": {"1735732805"}}, 0, false},
+		{"no headers", http.Header{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := serverRetryDelay(tt.header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("serverRetryDelay() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -2,43 +2,73 @@ package types
 
 // UserConfig 用户配置结构
 type UserConfig struct {
-	Users []UserSpec `yaml:"users"`
+	Include  []string            `yaml:"include,omitempty" json:"include,omitempty" toml:"include,omitempty"`    // 要合并的其他配置文件，支持相对路径和 glob，config.Load 合并后为空
+	Defaults *UserSpec           `yaml:"defaults,omitempty" json:"defaults,omitempty" toml:"defaults,omitempty"` // 所有用户共用的字段，config.Load 合并后为空
+	Profiles map[string]UserSpec `yaml:"profiles,omitempty" json:"profiles,omitempty" toml:"profiles,omitempty"` // 可通过 profile 引用的命名配置，config.Load 合并后为空
+	Users    []UserSpec          `yaml:"users" json:"users" toml:"users"`
+	Outputs  []OutputTarget      `yaml:"outputs,omitempty" json:"outputs,omitempty" toml:"outputs,omitempty"` // user create 写出结果的目标，与 --output 指定的目标一起生效
+}
+
+// OutputTarget 一个输出目标，所有目标都由同一次运行的 OutputConfig 生成
+type OutputTarget struct {
+	Path     string `yaml:"path" json:"path" toml:"path"`                                           // 输出文件路径，- 表示标准输出
+	Template string `yaml:"template,omitempty" json:"template,omitempty" toml:"template,omitempty"` // 模板文件或内置格式（dotenv、k8s-secret 等），为空时输出原始结果
+	Format   string `yaml:"format,omitempty" json:"format,omitempty" toml:"format,omitempty"`       // 原始结果的格式: yaml、json 或 toml，为空时根据扩展名判断
+	Mode     string `yaml:"mode,omitempty" json:"mode,omitempty" toml:"mode,omitempty"`             // 文件权限，例如 "0640"，默认 0600
+	Redact   bool   `yaml:"redact,omitempty" json:"redact,omitempty" toml:"redact,omitempty"`       // 密码和 Token 替换为 ${<USERNAME>_TOKEN} 形式的引用，不能用于内置格式
 }
 
 // UserSpec 用户规格定义
 type UserSpec struct {
-	NameMode string        `yaml:"nameMode,omitempty"` // 命名模式: "prefix" (添加时间戳) 或 "name" (不添加时间戳)，默认为 "prefix"
-	Username string        `yaml:"username"`
-	Email    string        `yaml:"email"`
-	Name     string        `yaml:"name"`
-	Password string        `yaml:"password"`
-	Token    *TokenSpec    `yaml:"token"`    // Personal Access Token 配置
-	Groups   []GroupSpec   `yaml:"groups"`   // 支持多个组
-	Projects []ProjectSpec `yaml:"projects"` // 用户级别的项目（不属于任何组）
+	NameMode     string            `yaml:"nameMode,omitempty" json:"nameMode,omitempty" toml:"nameMode,omitempty"`             // 命名策略: prefix（默认，添加运行 ID）、name（原样使用）、sequence、uuid、hash 或 template
+	NameTemplate string            `yaml:"nameTemplate,omitempty" json:"nameTemplate,omitempty" toml:"nameTemplate,omitempty"` // template 策略使用的 Go 模板，例如 {{.Prefix}}-{{.RunID}}-{{.Index}}
+	NameSeed     string            `yaml:"nameSeed,omitempty" json:"nameSeed,omitempty" toml:"nameSeed,omitempty"`             // hash 策略的种子，相同种子重复运行生成相同名称
+	Username     string            `yaml:"username" json:"username" toml:"username"`
+	Email        string            `yaml:"email" json:"email" toml:"email"`
+	Name         string            `yaml:"name" json:"name" toml:"name"`
+	Password     string            `yaml:"password" json:"password" toml:"password"`
+	Token        *TokenSpec        `yaml:"token,omitempty" json:"token,omitempty" toml:"token,omitempty"`          // Personal Access Token 配置
+	Groups       []GroupSpec       `yaml:"groups,omitempty" json:"groups,omitempty" toml:"groups,omitempty"`       // 支持多个组
+	Projects     []ProjectSpec     `yaml:"projects,omitempty" json:"projects,omitempty" toml:"projects,omitempty"` // 用户级别的项目（不属于任何组）
+	Profile      string            `yaml:"profile,omitempty" json:"profile,omitempty" toml:"profile,omitempty"`    // 引用 profiles 中的配置，本用户的字段覆盖其中的字段
+	Merge        map[string]string `yaml:"merge,omitempty" json:"merge,omitempty" toml:"merge,omitempty"`          // 列表的合并方式: replace（默认）、append 或 prepend，例如 groups: append
+	Expansion    `yaml:",inline"`
+}
+
+// Expansion 把一项复制多份的设置，由 config.Load 在处理前展开，展开后的项中为零值
+type Expansion struct {
+	Count  int                 `yaml:"count,omitempty" json:"count,omitempty" toml:"count,omitempty,omitzero"` // 复制的份数，值中可使用 ${index}
+	Matrix map[string][]string `yaml:"matrix,omitempty" json:"matrix,omitempty" toml:"matrix,omitempty"`       // 按变量取值的所有组合复制，值中可使用 ${变量名}
 }
 
 // TokenSpec Personal Access Token 规格定义
 type TokenSpec struct {
-	Scope     []string `yaml:"scope"`      // Token 的权限范围
-	ExpiresAt string   `yaml:"expires_at"` // 过期时间 (格式: YYYY-MM-DD)
+	Scope     []string `yaml:"scope" json:"scope" toml:"scope"`                // Token 的权限范围
+	ExpiresAt string   `yaml:"expires_at" json:"expires_at" toml:"expires_at"` // 过期时间 (格式: YYYY-MM-DD)
 }
 
 // GroupSpec 组规格定义
 type GroupSpec struct {
-	NameMode   string        `yaml:"nameMode,omitempty"` // 命名模式: "prefix" (添加时间戳) 或 "name" (不添加时间戳)，继承 UserSpec.NameMode
-	Name       string        `yaml:"name"`
-	Path       string        `yaml:"path"`
-	Visibility string        `yaml:"visibility"`
-	Projects   []ProjectSpec `yaml:"projects"` // 每个组下有多个项目
+	NameMode     string        `yaml:"nameMode,omitempty" json:"nameMode,omitempty" toml:"nameMode,omitempty"`             // 命名策略，未指定时继承 UserSpec.NameMode
+	NameTemplate string        `yaml:"nameTemplate,omitempty" json:"nameTemplate,omitempty" toml:"nameTemplate,omitempty"` // 未指定时继承 UserSpec.NameTemplate
+	NameSeed     string        `yaml:"nameSeed,omitempty" json:"nameSeed,omitempty" toml:"nameSeed,omitempty"`             // 未指定时继承 UserSpec.NameSeed
+	Name         string        `yaml:"name" json:"name" toml:"name"`
+	Path         string        `yaml:"path" json:"path" toml:"path"`
+	Visibility   string        `yaml:"visibility" json:"visibility" toml:"visibility"`
+	Projects     []ProjectSpec `yaml:"projects,omitempty" json:"projects,omitempty" toml:"projects,omitempty"` // 每个组下有多个项目
+	Expansion    `yaml:",inline"`
 }
 
 // ProjectSpec 项目规格定义
 type ProjectSpec struct {
-	NameMode    string `yaml:"nameMode,omitempty"` // 命名模式: "prefix" (添加时间戳) 或 "name" (不添加时间戳)，继承 GroupSpec.NameMode
-	Name        string `yaml:"name"`
-	Path        string `yaml:"path"`
-	Description string `yaml:"description"`
-	Visibility  string `yaml:"visibility"`
+	NameMode     string `yaml:"nameMode,omitempty" json:"nameMode,omitempty" toml:"nameMode,omitempty"`             // 命名策略，未指定时继承 GroupSpec.NameMode
+	NameTemplate string `yaml:"nameTemplate,omitempty" json:"nameTemplate,omitempty" toml:"nameTemplate,omitempty"` // 未指定时继承上级的 NameTemplate
+	NameSeed     string `yaml:"nameSeed,omitempty" json:"nameSeed,omitempty" toml:"nameSeed,omitempty"`             // 未指定时继承上级的 NameSeed
+	Name         string `yaml:"name" json:"name" toml:"name"`
+	Path         string `yaml:"path" json:"path" toml:"path"`
+	Description  string `yaml:"description" json:"description" toml:"description"`
+	Visibility   string `yaml:"visibility" json:"visibility" toml:"visibility"`
+	Expansion    `yaml:",inline"`
 }
 
 // ========================================
@@ -47,56 +77,58 @@ type ProjectSpec struct {
 
 // SSHConfig captures parsed SSH endpoint information
 type SSHConfig struct {
-	Endpoint string `yaml:"endpoint"` // Endpoint is the normalized SSH endpoint string
-	Host     string `yaml:"host"`     // Host is the SSH hostname extracted from the endpoint
-	Port     int    `yaml:"port"`     // Port is the SSH port number
+	Endpoint string `yaml:"endpoint" json:"endpoint" toml:"endpoint"` // Endpoint is the normalized SSH endpoint string
+	Host     string `yaml:"host" json:"host" toml:"host"`             // Host is the SSH hostname extracted from the endpoint
+	Port     int    `yaml:"port" json:"port" toml:"port"`             // Port is the SSH port number
 }
 
 // OutputConfig 输出配置结构
 type OutputConfig struct {
-	Endpoint string       `yaml:"endpoint"`      // Endpoint is the normalized HTTP endpoint of GitLab
-	Scheme   string       `yaml:"scheme"`        // Scheme is the HTTP scheme of the GitLab endpoint
-	Host     string       `yaml:"host"`          // Host is the hostname of the GitLab endpoint
-	Port     int          `yaml:"port"`          // Port is the HTTP port of the GitLab endpoint
-	SSH      *SSHConfig   `yaml:"ssh,omitempty"` // SSH holds parsed SSH endpoint details when available
-	Users    []UserOutput `yaml:"users"`         // Users carries all generated user outputs
+	Endpoint string       `yaml:"endpoint" json:"endpoint" toml:"endpoint"`                         // Endpoint is the normalized HTTP endpoint of GitLab
+	Scheme   string       `yaml:"scheme" json:"scheme" toml:"scheme"`                               // Scheme is the HTTP scheme of the GitLab endpoint
+	Host     string       `yaml:"host" json:"host" toml:"host"`                                     // Host is the hostname of the GitLab endpoint
+	Port     int          `yaml:"port" json:"port" toml:"port"`                                     // Port is the HTTP port of the GitLab endpoint
+	SSH      *SSHConfig   `yaml:"ssh,omitempty" json:"ssh,omitempty" toml:"ssh,omitempty"`          // SSH holds parsed SSH endpoint details when available
+	RunID    string       `yaml:"run_id,omitempty" json:"run_id,omitempty" toml:"run_id,omitempty"` // RunID identifies the run that generated the resources
+	Users    []UserOutput `yaml:"users" json:"users" toml:"users"`                                  // Users carries all generated user outputs
 }
 
 // UserOutput 用户输出结果
 type UserOutput struct {
-	Username string          `yaml:"username"`
-	Email    string          `yaml:"email"`
-	Name     string          `yaml:"name"`
-	UserID   int             `yaml:"user_id"`
-	Password string          `yaml:"password,omitempty"` // 用户密码
-	Token    *TokenOutput    `yaml:"token,omitempty"`
-	Groups   []GroupOutput   `yaml:"groups,omitempty"`
-	Projects []ProjectOutput `yaml:"projects,omitempty"` // 用户级别的项目
+	Username    string          `yaml:"username" json:"username" toml:"username"`
+	LogicalName string          `yaml:"logical_name,omitempty" json:"logical_name,omitempty" toml:"logical_name,omitempty"` // 配置文件中的用户名，模板中可用 lookup 按它查找用户
+	Email       string          `yaml:"email" json:"email" toml:"email"`
+	Name        string          `yaml:"name" json:"name" toml:"name"`
+	UserID      int             `yaml:"user_id" json:"user_id" toml:"user_id"`
+	Password    string          `yaml:"password,omitempty" json:"password,omitempty" toml:"password,omitempty"` // 用户密码
+	Token       *TokenOutput    `yaml:"token,omitempty" json:"token,omitempty" toml:"token,omitempty"`
+	Groups      []GroupOutput   `yaml:"groups,omitempty" json:"groups,omitempty" toml:"groups,omitempty"`
+	Projects    []ProjectOutput `yaml:"projects,omitempty" json:"projects,omitempty" toml:"projects,omitempty"` // 用户级别的项目
 }
 
 // TokenOutput Token 输出结果
 type TokenOutput struct {
-	Value     string   `yaml:"value"`
-	Scope     []string `yaml:"scope"`
-	ExpiresAt string   `yaml:"expires_at"`
+	Value     string   `yaml:"value" json:"value" toml:"value"`
+	Scope     []string `yaml:"scope" json:"scope" toml:"scope"`
+	ExpiresAt string   `yaml:"expires_at" json:"expires_at" toml:"expires_at"`
 }
 
 // GroupOutput 组输出结果
 type GroupOutput struct {
-	Name       string          `yaml:"name"`
-	Path       string          `yaml:"path"`
-	GroupID    int             `yaml:"group_id"`
-	Visibility string          `yaml:"visibility"`
-	Projects   []ProjectOutput `yaml:"projects,omitempty"`
+	Name       string          `yaml:"name" json:"name" toml:"name"`
+	Path       string          `yaml:"path" json:"path" toml:"path"`
+	GroupID    int             `yaml:"group_id" json:"group_id" toml:"group_id"`
+	Visibility string          `yaml:"visibility" json:"visibility" toml:"visibility"`
+	Projects   []ProjectOutput `yaml:"projects,omitempty" json:"projects,omitempty" toml:"projects,omitempty"`
 }
 
 // ProjectOutput 项目输出结果
 type ProjectOutput struct {
-	Name        string `yaml:"name"`
-	Path        string `yaml:"path"`         // 完整路径，如 group/project 或 username/project
-	ProjectPath string `yaml:"project_path"` // 项目本身的路径，不包含 group 或 username
-	ProjectID   int    `yaml:"project_id"`
-	Description string `yaml:"description"`
-	Visibility  string `yaml:"visibility"`
-	WebURL      string `yaml:"web_url,omitempty"`
+	Name        string `yaml:"name" json:"name" toml:"name"`
+	Path        string `yaml:"path" json:"path" toml:"path"`                         // 完整路径，如 group/project 或 username/project
+	ProjectPath string `yaml:"project_path" json:"project_path" toml:"project_path"` // 项目本身的路径，不包含 group 或 username
+	ProjectID   int    `yaml:"project_id" json:"project_id" toml:"project_id"`
+	Description string `yaml:"description" json:"description" toml:"description"`
+	Visibility  string `yaml:"visibility" json:"visibility" toml:"visibility"`
+	WebURL      string `yaml:"web_url,omitempty" json:"web_url,omitempty" toml:"web_url,omitempty"`
 }